@@ -2,13 +2,23 @@ package main
 
 import (
 	"context"
+	"crypto/subtle"
+	"flag"
 	"fmt"
 	"log"
+	"net"
+	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
+	_ "time/tzdata" // embed zone data so SLACK_TIMEZONE works on hosts without it (e.g. Windows)
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.mcconachie.co/slack-4-agents/internal/logrotate"
 	"go.mcconachie.co/slack-4-agents/internal/slack"
 	"go.mcconachie.co/slack-4-agents/internal/slackapi"
 	"go.mcconachie.co/slack-4-agents/internal/slackmcp"
@@ -18,36 +28,326 @@ import (
 
 var version = "dev"
 
+// shutdownTimeout bounds how long in-flight HTTP requests may run after a shutdown signal
+const shutdownTimeout = 10 * time.Second
+
 func main() {
-	if len(os.Args) > 1 && (os.Args[1] == "--version" || os.Args[1] == "-v") {
+	flags := flag.NewFlagSet("slack-4-agents", flag.ExitOnError)
+	showVersion := flags.Bool("version", false, "print the version and exit")
+	flags.BoolVar(showVersion, "v", false, "shorthand for -version")
+	logLevel := flags.String("log-level", "", "debug, info, warn or error (overrides LOG_LEVEL)")
+	logDirFlag := flags.String("log-dir", "", "directory for log files (overrides LOG_DIR; default <work-dir>/logs)")
+	workDirFlag := flags.String("work-dir", "", "data directory (overrides WORK_DIR; default ~/.claude/servers/slack-4-agents)")
+	selfTest := flags.Bool("selftest", false, "check each workspace's credentials and connectivity, then exit")
+	flags.Parse(os.Args[1:])
+	if flags.Arg(0) == "test" {
+		*selfTest = true
+	}
+
+	if *showVersion {
 		fmt.Println(version)
 		return
 	}
 
-	token := os.Getenv("SLACK_TOKEN")
-	cookie := os.Getenv("SLACK_COOKIE")
-	logLevel := os.Getenv("LOG_LEVEL")
+	cfg, err := createConfig()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *logLevel != "" {
+		cfg.LogLevel = *logLevel
+	}
+	if *workDirFlag != "" {
+		cfg.WorkDir = *workDirFlag
+	}
+	if *logDirFlag != "" {
+		cfg.LogDir = *logDirFlag
+	}
 
-	if token == "" {
-		log.Fatal("SLACK_TOKEN is required")
+	workDir := cfg.WorkDir
+	if workDir == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			log.Fatalf("Failed to get home directory: %v", err)
+		}
+		workDir = filepath.Join(homeDir, ".claude", "servers", "slack-4-agents")
+	}
+	logDir := cfg.LogDir
+	if logDir == "" {
+		logDir = filepath.Join(workDir, "logs")
 	}
 
-	homeDir, err := os.UserHomeDir()
+	initWorkDir(workDir)
+	workspaces, defaultWorkspace, err := loadWorkspaces(workDir, cfg)
 	if err != nil {
-		log.Fatalf("Failed to get home directory: %v", err)
+		log.Fatal(err)
 	}
+	logger := initLogger(cfg, logDir)
+	defer logger.Sync()
 
-	workDir := filepath.Join(homeDir, ".claude", "servers", "slack-4-agents")
-	logDir := filepath.Join(workDir, "logs")
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	initWorkDir(workDir)
-	logger := initLogger(logLevel, logDir)
-	defer logger.Sync()
+	services := initServices(logger, cfg, workDir, workspaces)
+	if *selfTest {
+		if !runSelfTest(ctx, os.Stdout, logger, services) {
+			logger.Sync()
+			os.Exit(1)
+		}
+		return
+	}
 
-	server := initServer(logger, token, cookie, workDir)
-	if err := server.Run(context.Background(), &mcp.StdioTransport{}); err != nil {
+	server := initServer(logger, services, defaultWorkspace)
+	if err := runServer(ctx, logger, server, cfg); err != nil && ctx.Err() == nil {
 		logger.Fatal("Server error", zap.Error(err))
 	}
+	logger.Info("Server shut down")
+}
+
+// runServer serves MCP over the configured transport until ctx is done or the transport fails
+func runServer(ctx context.Context, logger *zap.Logger, server *mcp.Server, cfg Config) error {
+	var handler http.Handler
+	getServer := func(*http.Request) *mcp.Server { return server }
+
+	switch cfg.Transport {
+	case "stdio":
+		return server.Run(ctx, &mcp.StdioTransport{})
+	case "http":
+		handler = mcp.NewStreamableHTTPHandler(getServer, nil)
+	case "sse":
+		handler = mcp.NewSSEHandler(getServer, nil)
+	default:
+		return fmt.Errorf("unsupported transport %q", cfg.Transport)
+	}
+
+	if cfg.AuthToken != "" {
+		handler = requireBearerToken(cfg.AuthToken, handler)
+	}
+
+	httpServer := &http.Server{
+		Addr:    net.JoinHostPort(cfg.Host, cfg.Port),
+		Handler: handler,
+	}
+	logger.Info("Serving MCP over HTTP",
+		zap.String("transport", cfg.Transport),
+		zap.String("addr", httpServer.Addr))
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- httpServer.ListenAndServe() }()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		logger.Info("Shutting down HTTP server")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		return httpServer.Shutdown(shutdownCtx)
+	}
+}
+
+// requireBearerToken rejects requests that do not carry token in an
+// "Authorization: Bearer" header
+func requireBearerToken(token string, next http.Handler) http.Handler {
+	want := []byte("Bearer " + token)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), want) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// isLoopbackHost reports whether host only accepts local connections. An
+// empty host listens on every interface, so it is not loopback.
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// Config holds the server configuration read from the environment
+type Config struct {
+	Token      string
+	TokenFile  string
+	Cookie     string
+	CookieFile string
+	LogLevel   string
+	LogDir     string
+	WorkDir    string
+	Transport  string
+	Host       string
+	Port       string
+	API        slackapi.Config
+	Slack      slack.Config
+
+	// LogMaxSizeMB, LogMaxBackups and LogMaxAge bound the log files kept in
+	// the log directory. Zero selects the default; negative disables the limit.
+	LogMaxSizeMB  int
+	LogMaxBackups int
+	LogMaxAge     time.Duration
+
+	// ResponseRetention is the age after which files in the responses
+	// directory are deleted at startup. Zero keeps files forever.
+	ResponseRetention time.Duration
+
+	// AuthToken, when set, is the bearer token HTTP clients must send. It is
+	// required for the http and sse transports unless Host is loopback.
+	AuthToken string
+}
+
+// createConfig reads the server configuration from environment variables.
+// SLACK_TOKEN_FILE and SLACK_COOKIE_FILE, when set, take precedence over the
+// inline SLACK_TOKEN and SLACK_COOKIE values.
+func createConfig() (Config, error) {
+	cfg := Config{
+		Token:      os.Getenv("SLACK_TOKEN"),
+		TokenFile:  os.Getenv("SLACK_TOKEN_FILE"),
+		Cookie:     os.Getenv("SLACK_COOKIE"),
+		CookieFile: os.Getenv("SLACK_COOKIE_FILE"),
+		LogLevel:   os.Getenv("LOG_LEVEL"),
+		LogDir:     os.Getenv("LOG_DIR"),
+		WorkDir:    os.Getenv("WORK_DIR"),
+		Transport:  os.Getenv("TRANSPORT"),
+		Host:       os.Getenv("HOST"),
+		Port:       os.Getenv("PORT"),
+		AuthToken:  os.Getenv("MCP_AUTH_TOKEN"),
+		API: slackapi.Config{
+			APIURL:   os.Getenv("SLACK_API_URL"),
+			ProxyURL: os.Getenv("SLACK_PROXY_URL"),
+		},
+		Slack: slack.Config{
+			Timezone:  os.Getenv("SLACK_TIMEZONE"),
+			ExportDir: os.Getenv("SLACK_EXPORT_DIR"),
+		},
+	}
+
+	var err error
+	if cfg.TokenFile != "" {
+		if cfg.Token, err = readSecretFile("SLACK_TOKEN_FILE", cfg.TokenFile); err != nil {
+			return Config{}, err
+		}
+	}
+	if cfg.CookieFile != "" {
+		if cfg.Cookie, err = readSecretFile("SLACK_COOKIE_FILE", cfg.CookieFile); err != nil {
+			return Config{}, err
+		}
+	}
+
+	switch cfg.Transport {
+	case "":
+		cfg.Transport = "stdio"
+	case "stdio", "http", "sse":
+	default:
+		return Config{}, fmt.Errorf("TRANSPORT: must be stdio, http, or sse (got %q)", cfg.Transport)
+	}
+	if cfg.Port == "" {
+		cfg.Port = "8080"
+	}
+	if cfg.Host == "" {
+		cfg.Host = "127.0.0.1"
+	}
+	if cfg.Transport != "stdio" && cfg.AuthToken == "" && !isLoopbackHost(cfg.Host) {
+		// Anyone who can reach the port could read Slack as the token's owner
+		return Config{}, fmt.Errorf("MCP_AUTH_TOKEN: required when HOST is not a loopback address (got %q)", cfg.Host)
+	}
+
+	if cfg.Slack.Retry.MaxAttempts, err = envInt("SLACK_RETRY_MAX_ATTEMPTS"); err != nil {
+		return Config{}, err
+	}
+	if cfg.Slack.Retry.BaseDelay, err = envDuration("SLACK_RETRY_BASE_DELAY"); err != nil {
+		return Config{}, err
+	}
+	if cfg.Slack.Retry.MaxDelay, err = envDuration("SLACK_RETRY_MAX_DELAY"); err != nil {
+		return Config{}, err
+	}
+	if cfg.Slack.RequestsPerSecond, err = envFloat("SLACK_REQUESTS_PER_SECOND"); err != nil {
+		return Config{}, err
+	}
+	if cfg.Slack.MaxChannelPages, err = envInt("SLACK_MAX_CHANNEL_PAGES"); err != nil {
+		return Config{}, err
+	}
+	if cfg.Slack.MaxInlineBytes, err = envInt("SLACK_MAX_INLINE_BYTES"); err != nil {
+		return Config{}, err
+	}
+	if cfg.Slack.ChannelInfoTTL, err = envDuration("SLACK_CHANNEL_INFO_TTL"); err != nil {
+		return Config{}, err
+	}
+	if cfg.Slack.ThreadPageSize, err = envInt("SLACK_THREAD_PAGE_SIZE"); err != nil {
+		return Config{}, err
+	}
+	if cfg.Slack.LinkCheckTimeout, err = envDuration("SLACK_LINK_CHECK_TIMEOUT"); err != nil {
+		return Config{}, err
+	}
+	if cfg.Slack.Retry.AttemptTimeout, err = envDuration("SLACK_REQUEST_TIMEOUT"); err != nil {
+		return Config{}, err
+	}
+	if cfg.LogMaxSizeMB, err = envInt("LOG_MAX_SIZE_MB"); err != nil {
+		return Config{}, err
+	}
+	if cfg.LogMaxBackups, err = envInt("LOG_MAX_BACKUPS"); err != nil {
+		return Config{}, err
+	}
+	if cfg.LogMaxAge, err = envDuration("LOG_MAX_AGE"); err != nil {
+		return Config{}, err
+	}
+
+	if cfg.ResponseRetention, err = envDuration("RESPONSE_RETENTION"); err != nil {
+		return Config{}, err
+	}
+
+	return cfg, nil
+}
+
+// readSecretFile reads a secret from path, trimming surrounding whitespace
+func readSecretFile(name, path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("%s: failed to read %q: %w", name, path, err)
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// envInt parses an optional integer environment variable, returning 0 if unset
+func envInt(name string) (int, error) {
+	v := os.Getenv(name)
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("%s: invalid integer %q", name, v)
+	}
+	return n, nil
+}
+
+// envFloat parses an optional decimal environment variable, returning 0 if unset
+func envFloat(name string) (float64, error) {
+	v := os.Getenv(name)
+	if v == "" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s: invalid number %q", name, v)
+	}
+	return f, nil
+}
+
+// envDuration parses an optional duration environment variable (e.g. "500ms"), returning 0 if unset
+func envDuration(name string) (time.Duration, error) {
+	v := os.Getenv(name)
+	if v == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("%s: invalid duration %q", name, v)
+	}
+	return d, nil
 }
 
 func initWorkDir(workDir string) {
@@ -58,34 +358,85 @@ func initWorkDir(workDir string) {
 	if err := os.MkdirAll(responseDir, 0o755); err != nil {
 		log.Fatalf("Failed to create responses directory: %v", err)
 	}
+	cacheDir := filepath.Join(workDir, "cache")
+	if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+		log.Fatalf("Failed to create cache directory: %v", err)
+	}
 }
 
-func initServer(logger *zap.Logger, token, cookie, workDir string) *mcp.Server {
+// initServices creates one Slack service per configured workspace
+func initServices(logger *zap.Logger, cfg Config, workDir string, workspaces map[string]WorkspaceConfig) map[string]*slack.Service {
 	logger.Info("Creating Slack client")
 
 	responseDir := filepath.Join(workDir, "responses")
 	responses := slack.NewFileResponseWriter(responseDir)
+	if cfg.ResponseRetention > 0 {
+		removed, err := responses.Cleanup(cfg.ResponseRetention)
+		if err != nil {
+			logger.Warn("Failed to clean up old response files", zap.Error(err))
+		} else if removed > 0 {
+			logger.Info("Removed old response files",
+				zap.Int("count", removed),
+				zap.Duration("retention", cfg.ResponseRetention))
+		}
+	}
 
-	api := slackapi.NewClient(token, cookie, logger)
-	client := slack.NewService(api, logger, responses)
+	cfg.Slack.CheckpointDir = filepath.Join(workDir, "cache")
+
+	services := make(map[string]*slack.Service, len(workspaces))
+	for name, ws := range workspaces {
+		wsLogger := logger
+		if name != "" {
+			wsLogger = logger.With(zap.String("workspace", name))
+		}
+		api, err := slackapi.NewClient(ws.Token, ws.Cookie, wsLogger, cfg.API)
+		if err != nil {
+			logger.Fatal("Failed to create Slack client", zap.String("workspace", name), zap.Error(err))
+		}
+		slackCfg := cfg.Slack
+		if name != "" {
+			// Keep export checkpoints apart, since channel IDs are only unique per workspace
+			slackCfg.CheckpointDir = filepath.Join(slackCfg.CheckpointDir, name)
+			if err := os.MkdirAll(slackCfg.CheckpointDir, 0o755); err != nil {
+				logger.Fatal("Failed to create cache directory", zap.String("workspace", name), zap.Error(err))
+			}
+		}
+		services[name] = slack.NewService(api, wsLogger, responses, slackCfg)
+	}
+	return services
+}
 
-	server := slackmcp.NewServer(logger, client)
+func initServer(logger *zap.Logger, services map[string]*slack.Service, defaultWorkspace string) *mcp.Server {
+	for name, svc := range services {
+		if _, err := svc.ResolveTimezone(context.Background()); err != nil {
+			logger.Warn("Failed to resolve display timezone, using UTC",
+				zap.String("workspace", name),
+				zap.Error(err))
+		}
+	}
+
+	ws, err := slackmcp.NewWorkspaces(services, defaultWorkspace)
+	if err != nil {
+		logger.Fatal("Failed to configure workspaces", zap.Error(err))
+	}
+	server := slackmcp.NewServer(logger, ws)
 	return server
 }
 
-func initLogger(level string, logDir string) *zap.Logger {
-	logLevel := interpretLogLevel(level)
+func initLogger(cfg Config, logDir string) *zap.Logger {
+	logLevel := interpretLogLevel(cfg.LogLevel)
 
 	encoderConfig := zap.NewProductionEncoderConfig()
 	encoderConfig.TimeKey = "timestamp"
 	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 
-	if err := os.MkdirAll(logDir, 0o755); err != nil {
-		log.Fatalf("Failed to create log directory: %v", err)
-	}
-	logFileName := fmt.Sprintf("slack-4-agents-%s.log", time.Now().Format("2006-01-02"))
-	logFilePath := filepath.Join(logDir, logFileName)
-	logFile, err := os.OpenFile(logFilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	logFile, err := logrotate.New(logrotate.Config{
+		Dir:        logDir,
+		Prefix:     "slack-4-agents",
+		MaxSize:    int64(cfg.LogMaxSizeMB) * 1024 * 1024,
+		MaxBackups: cfg.LogMaxBackups,
+		MaxAge:     cfg.LogMaxAge,
+	})
 	if err != nil {
 		log.Fatalf("Failed to open log file: %v", err)
 	}
@@ -102,7 +453,7 @@ func initLogger(level string, logDir string) *zap.Logger {
 		logLevel,
 	)
 
-	core := zapcore.NewTee(stderrCore, fileCore)
+	core := slackapi.NewRedactingCore(zapcore.NewTee(stderrCore, fileCore))
 
 	logger := zap.New(core, zap.AddCaller())
 	return logger
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	"go.mcconachie.co/slack-4-agents/internal/slack"
+	"go.uber.org/zap"
+)
+
+// runSelfTest checks that each workspace's credentials work by calling
+// auth.test and listing one page of channels, writing a pass/fail report
+// to out. It reports whether every check passed.
+func runSelfTest(ctx context.Context, out io.Writer, logger *zap.Logger, services map[string]*slack.Service) bool {
+	ok := true
+	for _, name := range slices.Sorted(maps.Keys(services)) {
+		svc := services[name]
+		if name != "" {
+			fmt.Fprintf(out, "Workspace %s:\n", name)
+		}
+
+		auth, err := svc.AuthTest(ctx, slack.AuthTestInput{})
+		if err != nil {
+			ok = false
+			fmt.Fprintf(out, "  FAIL auth.test: %v\n", slack.WrapError(logger, "auth_test", err))
+			continue
+		}
+		fmt.Fprintf(out, "  PASS auth.test: %s (%s) on %s (%s)\n", auth.User, auth.UserID, auth.Team, auth.TeamID)
+
+		channels, err := svc.ListChannels(ctx, slack.ListChannelsInput{Limit: 1})
+		if err != nil {
+			ok = false
+			fmt.Fprintf(out, "  FAIL conversations.list: %v\n", slack.WrapError(logger, "list_channels", err))
+			continue
+		}
+		fmt.Fprintf(out, "  PASS conversations.list: %d channel(s) returned\n", channels.TotalCount)
+	}
+
+	if ok {
+		fmt.Fprintln(out, "All checks passed")
+	} else {
+		fmt.Fprintln(out, "Some checks failed")
+	}
+	return ok
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// workspacesFileName is the optional file under the work directory that
+// configures several Slack workspaces
+const workspacesFileName = "workspaces.json"
+
+// WorkspaceConfig holds the credentials for one Slack workspace
+type WorkspaceConfig struct {
+	Token      string `json:"token"`
+	TokenFile  string `json:"token_file"`
+	Cookie     string `json:"cookie"`
+	CookieFile string `json:"cookie_file"`
+}
+
+// workspacesFile is the layout of workspaces.json
+type workspacesFile struct {
+	Default    string                     `json:"default"`
+	Workspaces map[string]WorkspaceConfig `json:"workspaces"`
+}
+
+// loadWorkspaces reads workspaces.json from workDir. Without the file, the
+// server uses a single unnamed workspace with the credentials in cfg.
+func loadWorkspaces(workDir string, cfg Config) (map[string]WorkspaceConfig, string, error) {
+	path := filepath.Join(workDir, workspacesFileName)
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		if cfg.Token == "" {
+			return nil, "", fmt.Errorf("SLACK_TOKEN or SLACK_TOKEN_FILE is required")
+		}
+		ws := WorkspaceConfig{Token: cfg.Token, Cookie: cfg.Cookie}
+		return map[string]WorkspaceConfig{"": ws}, "", nil
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read %q: %w", path, err)
+	}
+
+	var file workspacesFile
+	if err := json.Unmarshal(b, &file); err != nil {
+		return nil, "", fmt.Errorf("failed to parse %q: %w", path, err)
+	}
+	if len(file.Workspaces) == 0 {
+		return nil, "", fmt.Errorf("%s: no workspaces configured", path)
+	}
+	if _, ok := file.Workspaces[file.Default]; file.Default != "" && !ok {
+		return nil, "", fmt.Errorf("%s: default workspace %q is not configured", path, file.Default)
+	}
+
+	for name, ws := range file.Workspaces {
+		if name == "" {
+			return nil, "", fmt.Errorf("%s: workspace names must not be empty", path)
+		}
+		if ws.TokenFile != "" {
+			if ws.Token, err = readSecretFile(name+".token_file", ws.TokenFile); err != nil {
+				return nil, "", err
+			}
+		}
+		if ws.CookieFile != "" {
+			if ws.Cookie, err = readSecretFile(name+".cookie_file", ws.CookieFile); err != nil {
+				return nil, "", err
+			}
+		}
+		if ws.Token == "" {
+			return nil, "", fmt.Errorf("%s: workspace %q: token or token_file is required", path, name)
+		}
+		file.Workspaces[name] = ws
+	}
+	return file.Workspaces, file.Default, nil
+}
@@ -9,6 +9,7 @@ require (
 	github.com/slack-go/slack v0.17.3
 	go.uber.org/mock v0.6.0
 	go.uber.org/zap v1.27.1
+	golang.org/x/time v0.11.0
 )
 
 require (
@@ -0,0 +1,177 @@
+// Package logrotate provides a log file writer that starts a new file each
+// day or when the current file reaches a size limit, and prunes old files.
+package logrotate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultMaxSize    = 50 * 1024 * 1024
+	defaultMaxBackups = 10
+	defaultMaxAge     = 30 * 24 * time.Hour
+)
+
+// Config controls where logs are written and how many are kept.
+// Zero values select the defaults; a negative value disables that limit.
+type Config struct {
+	// Dir is the directory log files are written to
+	Dir string
+	// Prefix names the files: <prefix>-YYYY-MM-DD.log
+	Prefix string
+	// MaxSize is the size in bytes at which the current file is rolled over (default 50 MiB)
+	MaxSize int64
+	// MaxBackups is how many older log files are kept (default 10)
+	MaxBackups int
+	// MaxAge is how long older log files are kept (default 30 days)
+	MaxAge time.Duration
+}
+
+// Writer is an io.Writer over the current log file. It is safe for concurrent use.
+type Writer struct {
+	cfg Config
+	now func() time.Time
+
+	mu   sync.Mutex
+	file *os.File
+	day  string
+	size int64
+}
+
+// New opens (or appends to) today's log file in cfg.Dir
+func New(cfg Config) (*Writer, error) {
+	if cfg.MaxSize == 0 {
+		cfg.MaxSize = defaultMaxSize
+	}
+	if cfg.MaxBackups == 0 {
+		cfg.MaxBackups = defaultMaxBackups
+	}
+	if cfg.MaxAge == 0 {
+		cfg.MaxAge = defaultMaxAge
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	w := &Writer{cfg: cfg, now: time.Now}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Write appends p to the current file, first rolling over to a new file if
+// the day has changed or p would take the file past MaxSize.
+func (w *Writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.now().Format(time.DateOnly) != w.day {
+		w.file.Close()
+		if err := w.open(); err != nil {
+			return 0, err
+		}
+	} else if w.cfg.MaxSize > 0 && w.size > 0 && w.size+int64(len(p)) > w.cfg.MaxSize {
+		if err := w.rollover(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// Sync flushes the current file to disk
+func (w *Writer) Sync() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.file.Sync()
+}
+
+// Close closes the current file
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.file.Close()
+}
+
+// currentPath is the file written to today
+func (w *Writer) currentPath() string {
+	return filepath.Join(w.cfg.Dir, fmt.Sprintf("%s-%s.log", w.cfg.Prefix, w.day))
+}
+
+// open opens today's file for appending and prunes old files
+func (w *Writer) open() error {
+	w.day = w.now().Format(time.DateOnly)
+	f, err := os.OpenFile(w.currentPath(), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+	w.file, w.size = f, fi.Size()
+	w.prune()
+	return nil
+}
+
+// rollover moves the full current file aside and starts a new one
+func (w *Writer) rollover() error {
+	w.file.Close()
+	backup := filepath.Join(w.cfg.Dir, fmt.Sprintf("%s-%s.%s.log",
+		w.cfg.Prefix, w.day, w.now().Format("150405.000000000")))
+	if err := os.Rename(w.currentPath(), backup); err != nil {
+		return fmt.Errorf("failed to roll over log file: %w", err)
+	}
+	return w.open()
+}
+
+// prune removes older log files beyond MaxBackups or older than MaxAge.
+// Failures are ignored: pruning must never stop logging.
+func (w *Writer) prune() {
+	entries, err := os.ReadDir(w.cfg.Dir)
+	if err != nil {
+		return
+	}
+
+	type logFile struct {
+		path    string
+		modTime time.Time
+	}
+	current := w.currentPath()
+	var old []logFile
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasPrefix(name, w.cfg.Prefix+"-") || !strings.HasSuffix(name, ".log") {
+			continue
+		}
+		path := filepath.Join(w.cfg.Dir, name)
+		if path == current {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		old = append(old, logFile{path, info.ModTime()})
+	}
+
+	// Newest first
+	slices.SortFunc(old, func(a, b logFile) int { return b.modTime.Compare(a.modTime) })
+	for i, f := range old {
+		tooMany := w.cfg.MaxBackups > 0 && i >= w.cfg.MaxBackups
+		tooOld := w.cfg.MaxAge > 0 && w.now().Sub(f.modTime) > w.cfg.MaxAge
+		if tooMany || tooOld {
+			os.Remove(f.path)
+		}
+	}
+}
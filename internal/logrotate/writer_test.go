@@ -0,0 +1,93 @@
+package logrotate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func logFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	return names
+}
+
+func TestWriter_RollsOverAtMaxSize(t *testing.T) {
+	dir := t.TempDir()
+	w, err := New(Config{Dir: dir, Prefix: "test", MaxSize: 100})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer w.Close()
+
+	line := []byte(strings.Repeat("x", 59) + "\n")
+	for range 3 {
+		if _, err := w.Write(line); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+
+	// Each 60-byte line after the first overflows the 100-byte cap
+	names := logFiles(t, dir)
+	if len(names) != 3 {
+		t.Fatalf("files: got %v, want 3", names)
+	}
+	current := "test-" + time.Now().Format(time.DateOnly) + ".log"
+	data, err := os.ReadFile(filepath.Join(dir, current))
+	if err != nil {
+		t.Fatalf("read current file: %v", err)
+	}
+	if len(data) != len(line) {
+		t.Errorf("current file size: got %d, want %d", len(data), len(line))
+	}
+}
+
+func TestWriter_PrunesBackups(t *testing.T) {
+	dir := t.TempDir()
+
+	// Pre-existing files from earlier days, oldest first
+	for i, day := range []string{"2026-01-01", "2026-01-02", "2026-01-03"} {
+		path := filepath.Join(dir, "test-"+day+".log")
+		if err := os.WriteFile(path, []byte("old\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		mod := time.Now().Add(time.Duration(i-3) * time.Hour)
+		if err := os.Chtimes(path, mod, mod); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Past MaxAge regardless of the backup count
+	stale := filepath.Join(dir, "test-2025-01-01.log")
+	if err := os.WriteFile(stale, []byte("stale\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	staleTime := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(stale, staleTime, staleTime); err != nil {
+		t.Fatal(err)
+	}
+	// Unrelated files are left alone
+	if err := os.WriteFile(filepath.Join(dir, "other.log"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := New(Config{Dir: dir, Prefix: "test", MaxBackups: 2, MaxAge: 24 * time.Hour})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer w.Close()
+
+	got := strings.Join(logFiles(t, dir), ",")
+	want := "other.log,test-2026-01-02.log,test-2026-01-03.log,test-" + time.Now().Format(time.DateOnly) + ".log"
+	if got != want {
+		t.Errorf("files:\ngot:  %s\nwant: %s", got, want)
+	}
+}
@@ -0,0 +1,133 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// renderBlocks produces readable plain text from a message's Block Kit
+// blocks, one line per block (or per field and list item). Mentions keep
+// Slack's <@U...> and <#C...> form so they can be resolved like message
+// text. Blocks without text, such as images and dividers, are skipped.
+func renderBlocks(blocks slack.Blocks) string {
+	var lines []string
+	for _, block := range blocks.BlockSet {
+		switch b := block.(type) {
+		case *slack.HeaderBlock:
+			lines = appendText(lines, b.Text)
+		case *slack.SectionBlock:
+			lines = appendText(lines, b.Text)
+			for _, field := range b.Fields {
+				lines = appendText(lines, field)
+			}
+		case *slack.ContextBlock:
+			var parts []string
+			for _, el := range b.ContextElements.Elements {
+				if text, ok := el.(*slack.TextBlockObject); ok && text.Text != "" {
+					parts = append(parts, text.Text)
+				}
+			}
+			if len(parts) > 0 {
+				lines = append(lines, strings.Join(parts, " "))
+			}
+		case *slack.MarkdownBlock:
+			if b.Text != "" {
+				lines = append(lines, b.Text)
+			}
+		case *slack.RichTextBlock:
+			for _, el := range b.Elements {
+				lines = append(lines, renderRichTextElement(el, 0)...)
+			}
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// appendText appends a text object's text to lines if it has any
+func appendText(lines []string, text *slack.TextBlockObject) []string {
+	if text == nil || text.Text == "" {
+		return lines
+	}
+	return append(lines, text.Text)
+}
+
+// renderRichTextElement renders one rich_text element as lines, indenting
+// nested lists by depth
+func renderRichTextElement(el slack.RichTextElement, depth int) []string {
+	switch e := el.(type) {
+	case *slack.RichTextSection:
+		return splitLines(renderRichTextSection(e.Elements))
+	case *slack.RichTextQuote:
+		lines := splitLines(renderRichTextSection(e.Elements))
+		for i := range lines {
+			lines[i] = "> " + lines[i]
+		}
+		return lines
+	case *slack.RichTextPreformatted:
+		return splitLines(renderRichTextSection(e.Elements))
+	case *slack.RichTextList:
+		indent := strings.Repeat("  ", depth+e.Indent)
+		var lines []string
+		for i, item := range e.Elements {
+			if nested, ok := item.(*slack.RichTextList); ok {
+				lines = append(lines, renderRichTextElement(nested, depth+1)...)
+				continue
+			}
+			bullet := "• "
+			if e.Style == slack.RTEListOrdered {
+				bullet = fmt.Sprintf("%d. ", e.Offset+i+1)
+			}
+			for j, line := range renderRichTextElement(item, depth) {
+				if j == 0 {
+					line = bullet + line
+				}
+				lines = append(lines, indent+line)
+			}
+		}
+		return lines
+	}
+	return nil
+}
+
+// renderRichTextSection concatenates the inline elements of a rich text section
+func renderRichTextSection(elements []slack.RichTextSectionElement) string {
+	var sb strings.Builder
+	for _, el := range elements {
+		switch e := el.(type) {
+		case *slack.RichTextSectionTextElement:
+			sb.WriteString(e.Text)
+		case *slack.RichTextSectionLinkElement:
+			if e.Text != "" {
+				sb.WriteString(e.Text)
+			} else {
+				sb.WriteString(e.URL)
+			}
+		case *slack.RichTextSectionUserElement:
+			sb.WriteString("<@" + e.UserID + ">")
+		case *slack.RichTextSectionChannelElement:
+			sb.WriteString("<#" + e.ChannelID + ">")
+		case *slack.RichTextSectionUserGroupElement:
+			sb.WriteString("<!subteam^" + e.UsergroupID + ">")
+		case *slack.RichTextSectionBroadcastElement:
+			sb.WriteString("@" + e.Range)
+		case *slack.RichTextSectionEmojiElement:
+			sb.WriteString(":" + e.Name + ":")
+		case *slack.RichTextSectionDateElement:
+			if e.Fallback != nil {
+				sb.WriteString(*e.Fallback)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// splitLines splits rendered text into lines, dropping a trailing newline
+func splitLines(s string) []string {
+	s = strings.TrimSuffix(s, "\n")
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "\n")
+}
@@ -0,0 +1,65 @@
+package slack
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestRenderBlocks(t *testing.T) {
+	tests := []struct {
+		name   string
+		blocks string
+		want   string
+	}{
+		{
+			name:   "header",
+			blocks: `[{"type": "header", "text": {"type": "plain_text", "text": "Deploy finished"}}]`,
+			want:   "Deploy finished",
+		},
+		{
+			name: "section with fields",
+			blocks: `[{"type": "section", "text": {"type": "mrkdwn", "text": "*Alert:* CPU high"},
+				"fields": [{"type": "mrkdwn", "text": "*Host:* web-1"}, {"type": "mrkdwn", "text": "*Load:* 97%"}]}]`,
+			want: "*Alert:* CPU high\n*Host:* web-1\n*Load:* 97%",
+		},
+		{
+			name: "header, divider and context",
+			blocks: `[{"type": "header", "text": {"type": "plain_text", "text": "Weekly report"}},
+				{"type": "divider"},
+				{"type": "context", "elements": [{"type": "image", "image_url": "https://example.com/a.png", "alt_text": "logo"},
+					{"type": "mrkdwn", "text": "Generated by"}, {"type": "plain_text", "text": "reportbot"}]}]`,
+			want: "Weekly report\nGenerated by reportbot",
+		},
+		{
+			name: "rich text",
+			blocks: `[{"type": "rich_text", "elements": [
+				{"type": "rich_text_section", "elements": [{"type": "text", "text": "Hi "}, {"type": "user", "user_id": "U123"},
+					{"type": "text", "text": ", see "}, {"type": "link", "url": "https://example.com"}, {"type": "text", "text": " in "},
+					{"type": "channel", "channel_id": "C456"}, {"type": "emoji", "name": "tada"}]},
+				{"type": "rich_text_list", "style": "ordered", "elements": [
+					{"type": "rich_text_section", "elements": [{"type": "text", "text": "first"}]},
+					{"type": "rich_text_section", "elements": [{"type": "text", "text": "second"}]}]},
+				{"type": "rich_text_quote", "elements": [{"type": "text", "text": "quoted"}]}]}]`,
+			want: "Hi <@U123>, see https://example.com in <#C456>:tada:\n1. first\n2. second\n> quoted",
+		},
+		{
+			name:   "no text",
+			blocks: `[{"type": "divider"}]`,
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var blocks slack.Blocks
+			if err := json.Unmarshal([]byte(tt.blocks), &blocks); err != nil {
+				t.Fatalf("unmarshal blocks: %v", err)
+			}
+			if got := renderBlocks(blocks); got != tt.want {
+				t.Errorf("renderBlocks: got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
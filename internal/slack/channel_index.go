@@ -3,6 +3,7 @@ package slack
 import (
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/slack-go/slack"
 )
@@ -55,3 +56,51 @@ func (ix *channelIndex) Size() int {
 	defer ix.mu.RUnlock()
 	return len(ix.ids)
 }
+
+// channelInfoCache holds recent conversations.info results, including
+// Properties, for a short time. A nil cache never hits.
+type channelInfoCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[string]channelInfoEntry
+}
+
+type channelInfoEntry struct {
+	channel slack.Channel
+	expires time.Time
+}
+
+func newChannelInfoCache(ttl time.Duration) *channelInfoCache {
+	return &channelInfoCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]channelInfoEntry),
+	}
+}
+
+// Get returns a cached channel if present and unexpired. Safe for concurrent use.
+func (c *channelInfoCache) Get(id string) (*slack.Channel, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[id]
+	if !ok || c.now().After(e.expires) {
+		delete(c.entries, id)
+		return nil, false
+	}
+	ch := e.channel
+	return &ch, true
+}
+
+// Put caches a channel until the TTL elapses. Safe for concurrent use.
+func (c *channelInfoCache) Put(ch slack.Channel) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[ch.ID] = channelInfoEntry{channel: ch, expires: c.now().Add(c.ttl)}
+}
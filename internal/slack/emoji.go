@@ -0,0 +1,85 @@
+package slack
+
+import (
+	"strconv"
+	"strings"
+)
+
+// standardEmoji maps the short names Slack uses for common reactions to their
+// Unicode glyphs. Names not listed here (including custom workspace emoji) are
+// rendered as :name: instead.
+var standardEmoji = map[string]string{
+	"+1":                    "👍",
+	"thumbsup":              "👍",
+	"-1":                    "👎",
+	"thumbsdown":            "👎",
+	"heart":                 "❤️",
+	"joy":                   "😂",
+	"laughing":              "😆",
+	"smile":                 "😄",
+	"slightly_smiling_face": "🙂",
+	"grinning":              "😀",
+	"wink":                  "😉",
+	"thinking_face":         "🤔",
+	"open_mouth":            "😮",
+	"astonished":            "😲",
+	"cry":                   "😢",
+	"sob":                   "😭",
+	"scream":                "😱",
+	"sweat_smile":           "😅",
+	"pray":                  "🙏",
+	"clap":                  "👏",
+	"raised_hands":          "🙌",
+	"muscle":                "💪",
+	"ok_hand":               "👌",
+	"wave":                  "👋",
+	"eyes":                  "👀",
+	"fire":                  "🔥",
+	"tada":                  "🎉",
+	"rocket":                "🚀",
+	"100":                   "💯",
+	"star":                  "⭐",
+	"sparkles":              "✨",
+	"white_check_mark":      "✅",
+	"heavy_check_mark":      "✔️",
+	"x":                     "❌",
+	"warning":               "⚠️",
+	"question":              "❓",
+	"exclamation":           "❗",
+	"bulb":                  "💡",
+	"memo":                  "📝",
+	"point_up":              "☝️",
+	"raising_hand":          "🙋",
+	"party_popper":          "🎉",
+	"partying_face":         "🥳",
+	"heart_eyes":            "😍",
+	"saluting_face":         "🫡",
+	"melting_face":          "🫠",
+	"skull":                 "💀",
+	"see_no_evil":           "🙈",
+	"facepalm":              "🤦",
+	"shrug":                 "🤷",
+	"coffee":                "☕",
+	"beers":                 "🍻",
+	"bug":                   "🐛",
+}
+
+// reactionSummary renders reactions compactly for quick reading, e.g.
+// "👍3 ❤️2 :shipit:1". Skin tone modifiers are dropped so that variants of
+// the same emoji share a glyph.
+func reactionSummary(reactions []ReactionInfo) string {
+	var b strings.Builder
+	for i, r := range reactions {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		name, _, _ := strings.Cut(r.Name, "::")
+		if glyph, ok := standardEmoji[name]; ok {
+			b.WriteString(glyph)
+		} else {
+			b.WriteString(":" + r.Name + ":")
+		}
+		b.WriteString(strconv.Itoa(r.Count))
+	}
+	return b.String()
+}
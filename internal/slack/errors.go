@@ -1,9 +1,13 @@
 package slack
 
 import (
+	"errors"
 	"fmt"
+	"regexp"
 	"strings"
 
+	"github.com/slack-go/slack"
+
 	"go.uber.org/zap"
 )
 
@@ -14,29 +18,69 @@ var authErrorCodes = map[string]string{
 	"token_revoked":    "Authentication token has been revoked. Please generate new credentials.",
 	"account_inactive": "The Slack account is inactive or disabled.",
 	"not_authed":       "No authentication token provided. Please set SLACK_TOKEN and SLACK_COOKIE.",
+
+	"missing_scope":          "The token is missing an OAuth scope required by this method. Add the scope to your Slack app and reinstall it, or use a token with broader access.",
+	"not_allowed_token_type": "This method does not accept the type of token provided. Use a user token (xoxp- or xoxc-) rather than a bot token, or vice versa.",
 }
 
-// authError represents a Slack authentication error with guidance for resolution
-type authError struct {
-	Code    string
-	Message string
+// reNeededScopes extracts the "needed" scope list that Slack attaches to missing_scope errors
+var reNeededScopes = regexp.MustCompile(`needed["']?\s*[:=]\s*["']?([\w:.,]+)`)
+
+// AuthError represents a Slack authentication error with guidance for resolution
+type AuthError struct {
+	Code         string
+	Message      string
+	NeededScopes []string
 }
 
-func (e *authError) Error() string {
+func (e *AuthError) Error() string {
+	if len(e.NeededScopes) > 0 {
+		return fmt.Sprintf("SLACK AUTHENTICATION ERROR: %s Needed scopes: %s (code: %s)",
+			e.Message, strings.Join(e.NeededScopes, ", "), e.Code)
+	}
 	return fmt.Sprintf("SLACK AUTHENTICATION ERROR: %s (code: %s)", e.Message, e.Code)
 }
 
 // matchAuthError checks if an error contains an auth error code.
 // Returns nil if no auth error is found.
-func matchAuthError(err error) *authError {
+func matchAuthError(err error) *AuthError {
 	if err == nil {
 		return nil
 	}
 	errStr := err.Error()
 	for code, message := range authErrorCodes {
 		if strings.Contains(errStr, code) {
-			return &authError{Code: code, Message: message}
+			authErr := &AuthError{Code: code, Message: message}
+			if code == "missing_scope" {
+				authErr.NeededScopes = neededScopes(err)
+			}
+			return authErr
+		}
+	}
+	return nil
+}
+
+// neededScopes extracts the OAuth scopes a missing_scope error reports as needed,
+// searching the error text and any response metadata messages.
+func neededScopes(err error) []string {
+	candidates := []string{err.Error()}
+	var slackErr slack.SlackErrorResponse
+	if errors.As(err, &slackErr) {
+		candidates = append(candidates, slackErr.ResponseMetadata.Messages...)
+	}
+
+	for _, s := range candidates {
+		m := reNeededScopes.FindStringSubmatch(s)
+		if m == nil {
+			continue
+		}
+		var scopes []string
+		for _, scope := range strings.Split(m[1], ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				scopes = append(scopes, scope)
+			}
 		}
+		return scopes
 	}
 	return nil
 }
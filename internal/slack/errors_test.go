@@ -2,8 +2,11 @@ package slack
 
 import (
 	"errors"
+	"slices"
 	"testing"
 
+	"github.com/slack-go/slack"
+
 	"go.uber.org/zap/zaptest"
 )
 
@@ -44,6 +47,18 @@ func TestMatchAuthError(t *testing.T) {
 			wantCode: "invalid_auth",
 			wantMsg:  "Authentication token is invalid. Please refresh your SLACK_TOKEN and SLACK_COOKIE.",
 		},
+		{
+			name:     "missing_scope error",
+			err:      errors.New("missing_scope"),
+			wantCode: "missing_scope",
+			wantMsg:  "The token is missing an OAuth scope required by this method. Add the scope to your Slack app and reinstall it, or use a token with broader access.",
+		},
+		{
+			name:     "not_allowed_token_type error",
+			err:      errors.New("not_allowed_token_type"),
+			wantCode: "not_allowed_token_type",
+			wantMsg:  "This method does not accept the type of token provided. Use a user token (xoxp- or xoxc-) rather than a bot token, or vice versa.",
+		},
 		{
 			name:     "non-auth error",
 			err:      errors.New("channel_not_found"),
@@ -68,7 +83,7 @@ func TestMatchAuthError(t *testing.T) {
 				return
 			}
 			if got == nil {
-				t.Fatalf("matchAuthError() = nil, want authError")
+				t.Fatalf("matchAuthError() = nil, want AuthError")
 			}
 			if got.Code != tt.wantCode {
 				t.Errorf("matchAuthError().Code = %q, want %q", got.Code, tt.wantCode)
@@ -86,9 +101,9 @@ func TestWrapError_WhenAuthError_ReturnsAuthError(t *testing.T) {
 
 	wrapped := WrapError(logger, "test operation", err)
 
-	var authErr *authError
+	var authErr *AuthError
 	if !errors.As(wrapped, &authErr) {
-		t.Fatalf("expected authError, got %T", wrapped)
+		t.Fatalf("expected AuthError, got %T", wrapped)
 	}
 
 	if authErr.Code != "invalid_auth" {
@@ -107,9 +122,9 @@ func TestWrapError_WhenNonAuthError_WrapsOriginal(t *testing.T) {
 
 	wrapped := WrapError(logger, "test operation", originalErr)
 
-	var authErr *authError
+	var authErr *AuthError
 	if errors.As(wrapped, &authErr) {
-		t.Fatalf("expected non-authError, got authError")
+		t.Fatalf("expected non-AuthError, got AuthError")
 	}
 
 	wantErrStr := "test operation: channel_not_found"
@@ -129,7 +144,7 @@ func TestWrapError_WhenNil_ReturnsNil(t *testing.T) {
 }
 
 func TestAuthError_Error_FormatsCodeAndMessage(t *testing.T) {
-	err := &authError{
+	err := &AuthError{
 		Code:    "invalid_auth",
 		Message: "Test message",
 	}
@@ -139,3 +154,62 @@ func TestAuthError_Error_FormatsCodeAndMessage(t *testing.T) {
 		t.Errorf("Error(): got %q, want %q", got, want)
 	}
 }
+
+func TestMatchAuthError_MissingScopeExtractsNeededScopes(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want []string
+	}{
+		{
+			name: "needed in error text",
+			err:  errors.New("missing_scope (needed: channels:history, provided: users:read)"),
+			want: []string{"channels:history"},
+		},
+		{
+			name: "multiple needed scopes",
+			err:  errors.New(`missing_scope needed="groups:read,im:read"`),
+			want: []string{"groups:read", "im:read"},
+		},
+		{
+			name: "needed in response metadata",
+			err: slack.SlackErrorResponse{
+				Err: "missing_scope",
+				ResponseMetadata: slack.ResponseMetadata{
+					Messages: []string{"[ERROR] needed: search:read"},
+				},
+			},
+			want: []string{"search:read"},
+		},
+		{
+			name: "no needed field",
+			err:  errors.New("missing_scope"),
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := matchAuthError(tt.err)
+			if got == nil {
+				t.Fatal("matchAuthError() = nil, want AuthError")
+			}
+			if !slices.Equal(got.NeededScopes, tt.want) {
+				t.Errorf("NeededScopes: got %v, want %v", got.NeededScopes, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthError_Error_IncludesNeededScopes(t *testing.T) {
+	err := &AuthError{
+		Code:         "missing_scope",
+		Message:      "Test message",
+		NeededScopes: []string{"channels:history", "groups:history"},
+	}
+
+	want := "SLACK AUTHENTICATION ERROR: Test message Needed scopes: channels:history, groups:history (code: missing_scope)"
+	if got := err.Error(); got != want {
+		t.Errorf("Error(): got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,256 @@
+package slack
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// exportCheckpoint records how far the history pass of an export got, so an
+// interrupted export can continue from the last completed page. It is kept
+// until the thread files are written as well, so a failure there resumes
+// without fetching the history again.
+type exportCheckpoint struct {
+	ChannelID string `json:"channel_id"`
+	Oldest    string `json:"oldest,omitempty"`
+	Latest    string `json:"latest,omitempty"`
+
+	// Options are the settings that shaped the lines already written; a
+	// resume with different ones would mix two formats in one file.
+	Options exportOptions `json:"options"`
+
+	// Cursor is the next history page to fetch.
+	Cursor string `json:"cursor"`
+	// TempPath is the partial history file; Bytes is how much of it is complete.
+	TempPath string `json:"temp_path"`
+	Bytes    int64  `json:"bytes"`
+
+	// ThreadsPath holds the thread parents seen so far, one JSON message per
+	// line, so the checkpoint itself stays small; ThreadBytes is how much of
+	// it is complete.
+	ThreadsPath string `json:"threads_path"`
+	ThreadBytes int64  `json:"thread_bytes"`
+
+	// Complete is set once the whole history has been written.
+	Complete bool `json:"complete,omitempty"`
+
+	MessageCount  int            `json:"message_count"`
+	ThreadCount   int            `json:"thread_count"`
+	ReactionCount int            `json:"reaction_count"`
+	Reactions     map[string]int `json:"reactions,omitempty"`
+	UserPosts     map[string]int `json:"user_posts,omitempty"`
+}
+
+// exportOptions are the ExportChannelInput fields that change what is
+// written for each history message
+type exportOptions struct {
+	IncludeShared     bool `json:"include_shared,omitempty"`
+	IncludePermalinks bool `json:"include_permalinks,omitempty"`
+	IncludeBlocks     bool `json:"include_blocks,omitempty"`
+	ExcludeBots       bool `json:"exclude_bots,omitempty"`
+}
+
+func exportOptionsFrom(input ExportChannelInput) exportOptions {
+	return exportOptions{
+		IncludeShared:     input.IncludeShared,
+		IncludePermalinks: input.IncludePermalinks,
+		IncludeBlocks:     input.IncludeBlocks,
+		ExcludeBots:       input.ExcludeBots,
+	}
+}
+
+func newExportCheckpoint(
+	channelID string,
+	input ExportChannelInput,
+	cursor, tmpPath string,
+	bytes int64,
+	threadsPath string,
+	threadBytes int64,
+	stats *exportStats,
+) *exportCheckpoint {
+	cp := &exportCheckpoint{
+		ChannelID:     channelID,
+		Oldest:        input.Oldest,
+		Latest:        input.Latest,
+		Options:       exportOptionsFrom(input),
+		Cursor:        cursor,
+		TempPath:      tmpPath,
+		Bytes:         bytes,
+		ThreadsPath:   threadsPath,
+		ThreadBytes:   threadBytes,
+		MessageCount:  stats.messageCount,
+		ThreadCount:   stats.threadCount,
+		ReactionCount: stats.reactionCount,
+		Reactions:     maps.Clone(stats.reactions),
+		UserPosts:     maps.Clone(stats.userPosts),
+	}
+	return cp
+}
+
+// restore copies the saved statistics into stats
+func (cp *exportCheckpoint) restore(stats *exportStats) {
+	stats.messageCount = cp.MessageCount
+	stats.threadCount = cp.ThreadCount
+	stats.reactionCount = cp.ReactionCount
+	maps.Copy(stats.reactions, cp.Reactions)
+	maps.Copy(stats.userPosts, cp.UserPosts)
+}
+
+// reopen opens the partial history file for appending, discarding anything
+// written after the checkpoint, and returns the offsets of the saved lines.
+func (cp *exportCheckpoint) reopen() (*os.File, []int64, error) {
+	f, err := os.OpenFile(cp.TempPath, os.O_RDWR, 0)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to reopen checkpointed export: %w", err)
+	}
+	if err := f.Truncate(cp.Bytes); err != nil {
+		f.Close()
+		return nil, nil, fmt.Errorf("failed to truncate checkpointed export: %w", err)
+	}
+
+	// Every line ends in a newline, so each line after the first starts just past one.
+	var offsets []int64
+	if cp.Bytes > 0 {
+		offsets = append(offsets, 0)
+	}
+	var pos int64
+	buf := make([]byte, 64*1024)
+	r := io.LimitReader(f, cp.Bytes)
+	for {
+		n, err := r.Read(buf)
+		for i, b := range buf[:n] {
+			if b == '\n' && pos+int64(i)+1 < cp.Bytes {
+				offsets = append(offsets, pos+int64(i)+1)
+			}
+		}
+		pos += int64(n)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			f.Close()
+			return nil, nil, fmt.Errorf("failed to read checkpointed export: %w", err)
+		}
+	}
+
+	if _, err := f.Seek(cp.Bytes, io.SeekStart); err != nil {
+		f.Close()
+		return nil, nil, fmt.Errorf("failed to seek checkpointed export: %w", err)
+	}
+	return f, offsets, nil
+}
+
+// reopenThreads opens the thread parents file for appending, discarding
+// anything written after the checkpoint, and returns the saved parents.
+func (cp *exportCheckpoint) reopenThreads() (*os.File, []slack.Message, error) {
+	f, err := os.OpenFile(cp.ThreadsPath, os.O_RDWR, 0)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to reopen checkpointed threads: %w", err)
+	}
+	if err := f.Truncate(cp.ThreadBytes); err != nil {
+		f.Close()
+		return nil, nil, fmt.Errorf("failed to truncate checkpointed threads: %w", err)
+	}
+
+	var threads []slack.Message
+	dec := json.NewDecoder(io.LimitReader(f, cp.ThreadBytes))
+	for {
+		var msg slack.Message
+		if err := dec.Decode(&msg); err == io.EOF {
+			break
+		} else if err != nil {
+			f.Close()
+			return nil, nil, fmt.Errorf("failed to read checkpointed threads: %w", err)
+		}
+		threads = append(threads, msg)
+	}
+
+	if _, err := f.Seek(cp.ThreadBytes, io.SeekStart); err != nil {
+		f.Close()
+		return nil, nil, fmt.Errorf("failed to seek checkpointed threads: %w", err)
+	}
+	return f, threads, nil
+}
+
+// checkpointPath returns where the checkpoint for an export is stored, or ""
+// if checkpointing is disabled.
+func (c *Service) checkpointPath(channelID string, input ExportChannelInput) string {
+	if c.checkpointDir == "" {
+		return ""
+	}
+	oldest, latest := input.Oldest, input.Latest
+	if oldest == "" {
+		oldest = "start"
+	}
+	if latest == "" {
+		latest = "now"
+	}
+	name := fmt.Sprintf("export-%s-%s-%s.json", channelID, oldest, latest)
+	return filepath.Join(c.checkpointDir, strings.ReplaceAll(name, string(filepath.Separator), "_"))
+}
+
+// loadCheckpoint reads a checkpoint file, returning nil if there is none or
+// the files it references have since been removed.
+func loadCheckpoint(path string) (*exportCheckpoint, error) {
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+
+	var cp exportCheckpoint
+	if err := json.Unmarshal(b, &cp); err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint: %w", err)
+	}
+	for _, p := range []string{cp.TempPath, cp.ThreadsPath} {
+		if _, err := os.Stat(p); err != nil {
+			return nil, nil
+		}
+	}
+	return &cp, nil
+}
+
+// discardCheckpoint removes the checkpoint at path along with the files it
+// references, so starting over doesn't orphan the old ones.
+func discardCheckpoint(path string) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	var cp exportCheckpoint
+	if json.Unmarshal(b, &cp) == nil {
+		for _, p := range []string{cp.TempPath, cp.ThreadsPath} {
+			if p != "" {
+				os.Remove(p)
+			}
+		}
+	}
+	os.Remove(path)
+}
+
+// saveCheckpoint atomically replaces the checkpoint file at path
+func saveCheckpoint(path string, cp *exportCheckpoint) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create checkpoint: %w", err)
+	}
+	if err := json.NewEncoder(f).Encode(cp); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return fmt.Errorf("failed to write checkpoint: %w", err)
+	}
+	if _, err := commitTempFile(f, path); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return nil
+}
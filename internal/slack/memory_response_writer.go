@@ -0,0 +1,137 @@
+package slack
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// memoryPathPrefix marks the synthetic paths returned by MemoryResponseWriter
+const memoryPathPrefix = "memory://"
+
+// MemoryResponseWriter keeps response data in memory instead of on disk.
+// It is intended for tests and small outputs that are returned inline.
+type MemoryResponseWriter struct {
+	mu    sync.Mutex
+	files map[string][]byte
+
+	// dir is scratch space created on first use by Dir and removed by Close
+	dirOnce sync.Once
+	dir     string
+}
+
+// NewMemoryResponseWriter creates an empty in-memory response writer
+func NewMemoryResponseWriter() *MemoryResponseWriter {
+	return &MemoryResponseWriter{files: make(map[string][]byte)}
+}
+
+// Bytes returns the content written for ref, or false if ref was not written by w
+func (w *MemoryResponseWriter) Bytes(ref FileRef) ([]byte, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	b, ok := w.files[ref.Name]
+	return b, ok
+}
+
+// Read returns the content stored under name
+func (w *MemoryResponseWriter) Read(name string) ([]byte, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	b, ok := w.files[name]
+	if !ok {
+		return nil, fmt.Errorf("response %q: %w", name, os.ErrNotExist)
+	}
+	return b, nil
+}
+
+// Open returns a reader over the content stored under name
+func (w *MemoryResponseWriter) Open(name string) (io.ReadCloser, error) {
+	b, err := w.Read(name)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(b)), nil
+}
+
+// Dir returns a temp directory private to w, for callers that need scratch
+// space on disk (e.g. the two-pass export). Files written there are not
+// tracked. The directory is created on first use; call Close to remove it.
+// If it can't be created, the system temp directory is returned instead.
+func (w *MemoryResponseWriter) Dir() string {
+	w.dirOnce.Do(func() {
+		dir, err := os.MkdirTemp("", "slack-4-agents-memory-*")
+		if err != nil {
+			dir = os.TempDir()
+		}
+		w.dir = dir
+	})
+	return w.dir
+}
+
+// Close removes the scratch directory returned by Dir, if one was created
+func (w *MemoryResponseWriter) Close() error {
+	w.dirOnce.Do(func() {})
+	if w.dir == "" || w.dir == os.TempDir() {
+		return nil
+	}
+	return os.RemoveAll(w.dir)
+}
+
+// WriteJSON marshals data to indented JSON under a timestamped name
+func (w *MemoryResponseWriter) WriteJSON(name string, data any) (FileRef, error) {
+	var buf bytes.Buffer
+	if err := encodeJSON(&buf, data); err != nil {
+		return FileRef{}, err
+	}
+	return w.store(fmt.Sprintf("%s-%d.json", name, time.Now().UnixNano()), buf.Bytes(), 1), nil
+}
+
+// WriteJSONGzip marshals data to gzip-compressed JSON under a timestamped name
+func (w *MemoryResponseWriter) WriteJSONGzip(name string, data any) (FileRef, error) {
+	var buf bytes.Buffer
+	if err := encodeJSONGzip(&buf, data); err != nil {
+		return FileRef{}, err
+	}
+	return w.store(fmt.Sprintf("%s-%d.json.gz", name, time.Now().UnixNano()), buf.Bytes(), 1), nil
+}
+
+// WriteJSONLines writes JSON-lines data under a timestamped name
+func (w *MemoryResponseWriter) WriteJSONLines(name string, writeFn func(jw JSONLineWriter) error) (FileRef, error) {
+	return w.WriteJSONLinesNamed(fmt.Sprintf("%s-%d.jsonl", name, time.Now().UnixNano()), writeFn)
+}
+
+// WriteJSONLinesNamed writes JSON-lines data under the given name
+func (w *MemoryResponseWriter) WriteJSONLinesNamed(filename string, writeFn func(jw JSONLineWriter) error) (FileRef, error) {
+	var buf bytes.Buffer
+	jw := &jsonLineWriter{bw: bufio.NewWriter(&buf)}
+	if err := writeFn(jw); err != nil {
+		return FileRef{}, err
+	}
+	if err := jw.bw.Flush(); err != nil {
+		return FileRef{}, fmt.Errorf("failed to flush buffer: %w", err)
+	}
+	return w.store(filename, buf.Bytes(), jw.lines), nil
+}
+
+// WriteText stores plain text content under a timestamped name
+func (w *MemoryResponseWriter) WriteText(name string, content string) (FileRef, error) {
+	filename := fmt.Sprintf("%s-%d.txt", name, time.Now().UnixNano())
+	return w.store(filename, []byte(content), countLines(content)), nil
+}
+
+func (w *MemoryResponseWriter) store(filename string, data []byte, lines int) FileRef {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.files[filename] = data
+	return FileRef{
+		Path:  memoryPathPrefix + filename,
+		Name:  filename,
+		URI:   ResponseURIPrefix + filename,
+		Bytes: int64(len(data)),
+		Lines: lines,
+	}
+}
@@ -0,0 +1,112 @@
+package slack
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+)
+
+var _ ResponseWriter = (*MemoryResponseWriter)(nil)
+
+func TestMemoryResponseWriter_JSON(t *testing.T) {
+	w := NewMemoryResponseWriter()
+
+	want := map[string]int{"a": 1, "b": 2}
+	ref, err := w.WriteJSON("data", want)
+	if err != nil {
+		t.Fatalf("WriteJSON failed: %v", err)
+	}
+
+	if !strings.HasPrefix(ref.Path, memoryPathPrefix) || !strings.HasSuffix(ref.Name, ".json") {
+		t.Errorf("ref: got Path=%q Name=%q, want memory path and .json name", ref.Path, ref.Name)
+	}
+
+	data, ok := w.Bytes(ref)
+	if !ok {
+		t.Fatal("Bytes: got not found, want content")
+	}
+	if int64(len(data)) != ref.Bytes {
+		t.Errorf("Bytes: got %d, want %d", ref.Bytes, len(data))
+	}
+
+	var got map[string]int
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+	if got["a"] != 1 || got["b"] != 2 {
+		t.Errorf("round trip: got %v, want %v", got, want)
+	}
+}
+
+func TestMemoryResponseWriter_JSONLines(t *testing.T) {
+	w := NewMemoryResponseWriter()
+
+	ref, err := w.WriteJSONLinesNamed("thread.jsonl", func(jw JSONLineWriter) error {
+		for i := range 3 {
+			if err := jw.WriteLine(map[string]int{"n": i}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WriteJSONLinesNamed failed: %v", err)
+	}
+
+	if ref.Name != "thread.jsonl" || ref.Lines != 3 {
+		t.Errorf("ref: got Name=%q Lines=%d, want thread.jsonl/3", ref.Name, ref.Lines)
+	}
+
+	data, _ := w.Bytes(ref)
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	for i, line := range lines {
+		var got map[string]int
+		if err := json.Unmarshal([]byte(line), &got); err != nil {
+			t.Fatalf("line %d: failed to unmarshal: %v", i, err)
+		}
+		if got["n"] != i {
+			t.Errorf("line %d: got n=%d, want %d", i, got["n"], i)
+		}
+	}
+}
+
+func TestMemoryResponseWriter_Text(t *testing.T) {
+	w := NewMemoryResponseWriter()
+
+	ref, err := w.WriteText("canvas", "line one\nline two")
+	if err != nil {
+		t.Fatalf("WriteText failed: %v", err)
+	}
+	if ref.Lines != 2 {
+		t.Errorf("Lines: got %d, want 2", ref.Lines)
+	}
+
+	if _, ok := w.Bytes(FileRef{Name: "missing.txt"}); ok {
+		t.Error("Bytes: got found for unknown ref, want not found")
+	}
+}
+
+func TestMemoryResponseWriter_DirIsPrivate(t *testing.T) {
+	a, b := NewMemoryResponseWriter(), NewMemoryResponseWriter()
+	defer a.Close()
+	defer b.Close()
+
+	dir := a.Dir()
+	if dir == os.TempDir() || dir == b.Dir() {
+		t.Fatalf("Dir: got %q (other writer %q), want a directory private to the writer", dir, b.Dir())
+	}
+	if a.Dir() != dir {
+		t.Errorf("Dir: got %q on second call, want %q", a.Dir(), dir)
+	}
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		t.Fatalf("Dir %q: stat got %v, want an existing directory", dir, err)
+	}
+
+	if err := a.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("Dir %q after Close: stat got %v, want not exist", dir, err)
+	}
+}
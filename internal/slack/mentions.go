@@ -0,0 +1,41 @@
+package slack
+
+import "regexp"
+
+// reMention matches Slack's angle-bracket entity syntax for users, channels,
+// and special mentions, with an optional |label suffix.
+var reMention = regexp.MustCompile(`<([@#!])([A-Za-z0-9^]+)(?:\|([^>]*))?>`)
+
+// renderMentions rewrites <@U123>, <#C123|name>, and <!here> entities as
+// readable @name, #name, and @here text. Unlabelled IDs are resolved with
+// userName and channelName; unresolvable IDs are rendered as-is.
+func renderMentions(text string, userName, channelName func(string) string) string {
+	return reMention.ReplaceAllStringFunc(text, func(token string) string {
+		m := reMention.FindStringSubmatch(token)
+		kind, id, label := m[1], m[2], m[3]
+
+		switch kind {
+		case "@":
+			if label != "" {
+				return "@" + label
+			}
+			if name := userName(id); name != "" {
+				return "@" + name
+			}
+			return "@" + id
+		case "#":
+			if label != "" {
+				return "#" + label
+			}
+			if name := channelName(id); name != "" {
+				return "#" + name
+			}
+			return "#" + id
+		default:
+			if label != "" {
+				return label
+			}
+			return "@" + id
+		}
+	})
+}
@@ -0,0 +1,35 @@
+package slack
+
+import "testing"
+
+func TestRenderMentions(t *testing.T) {
+	users := map[string]string{"U111": "alice"}
+	channels := map[string]string{"C222": "general"}
+	userName := func(id string) string { return users[id] }
+	channelName := func(id string) string { return channels[id] }
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"user resolved", "hi <@U111>", "hi @alice"},
+		{"user with label", "hi <@U999|bob>", "hi @bob"},
+		{"user unresolved", "hi <@U999>", "hi @U999"},
+		{"channel with label", "see <#C333|random>", "see #random"},
+		{"channel resolved", "see <#C222>", "see #general"},
+		{"channel unresolved", "see <#C999>", "see #C999"},
+		{"special mention", "<!here> ping", "@here ping"},
+		{"subteam with label", "<!subteam^S123|@oncall> ping", "@oncall ping"},
+		{"links untouched", "<https://example.com|docs>", "<https://example.com|docs>"},
+		{"plain text", "nothing to see", "nothing to see"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := renderMentions(tt.input, userName, channelName); got != tt.want {
+				t.Errorf("renderMentions(%q): got %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
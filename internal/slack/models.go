@@ -2,20 +2,74 @@ package slack
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
 )
 
 // MessageInfo represents a Slack message
 type MessageInfo struct {
-	Timestamp        string         `json:"timestamp"`
-	TimestampDisplay string         `json:"timestamp_display,omitempty"`
-	User             string         `json:"user"`
-	UserName         string         `json:"user_name,omitempty"`
-	Text             string         `json:"text"`
-	ThreadTimestamp  string         `json:"thread_ts,omitempty"`
-	ReplyCount       int            `json:"reply_count,omitempty"`
-	Reactions        []ReactionInfo `json:"reactions,omitempty"`
+	Timestamp       Timestamp `json:"timestamp"`
+	RawTimestamp    string    `json:"raw_timestamp"`
+	User            string    `json:"user"`
+	UserName        string    `json:"user_name,omitempty"`
+	Text            string    `json:"text"`
+	ThreadTimestamp Timestamp `json:"thread_ts,omitempty"`
+	// RawThreadTimestamp is ThreadTimestamp as Slack sent it, for follow-up
+	// calls such as slack_read_thread
+	RawThreadTimestamp string         `json:"raw_thread_ts,omitempty"`
+	ReplyCount         int            `json:"reply_count,omitempty"`
+	Reactions          []ReactionInfo `json:"reactions,omitempty"`
+	ReactionSummary    string         `json:"reaction_summary,omitempty"`
+	Shared             *SharedMessage `json:"shared,omitempty"`
+	SubType            string         `json:"subtype,omitempty"`
+	Files              []FileInfo     `json:"files,omitempty"`
+	Permalink          string         `json:"permalink,omitempty"`
+	Edited             *EditedInfo    `json:"edited,omitempty"`
+
+	// Blocks and Attachments hold the message's raw Block Kit and attachment
+	// JSON. They are only filled by exports with include_blocks set.
+	Blocks      json.RawMessage `json:"blocks,omitempty"`
+	Attachments json.RawMessage `json:"attachments,omitempty"`
+}
+
+// EditedInfo records who last edited a message and when
+type EditedInfo struct {
+	User      string    `json:"user"`
+	Timestamp Timestamp `json:"timestamp"`
+}
+
+// editedInfo returns the edit metadata for msg, or nil if it was never edited
+func editedInfo(msg slack.Message, loc *time.Location) *EditedInfo {
+	if msg.Edited == nil {
+		return nil
+	}
+	return &EditedInfo{User: msg.Edited.User, Timestamp: newTimestamp(msg.Edited.Timestamp, loc)}
+}
+
+// FileInfo describes a file attached to a message
+type FileInfo struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Title     string `json:"title,omitempty"`
+	Filetype  string `json:"filetype,omitempty"`
+	Bytes     int    `json:"bytes"`
+	Permalink string `json:"permalink,omitempty"`
+}
+
+// SharedMessage represents a message quoted or forwarded into another message
+type SharedMessage struct {
+	Channel   string `json:"channel,omitempty"`
+	User      string `json:"user,omitempty"`
+	Text      string `json:"text"`
+	Timestamp string `json:"timestamp,omitempty"`
 }
 
 // ReactionInfo represents an emoji reaction with its count
@@ -24,20 +78,121 @@ type ReactionInfo struct {
 	Count int    `json:"count"`
 }
 
-// formatSlackTimestamp converts a Slack timestamp (e.g. "1234567890.123456") to ISO 8601.
-func formatSlackTimestamp(ts string) string {
+// Timestamp is a time formatted for output as RFC3339 in the workspace's
+// display time zone. Build one with newTimestamp, and use the accompanying
+// raw field for follow-up API calls.
+type Timestamp string
+
+// newTimestamp formats a Slack timestamp (e.g. "1234567890.123456") in loc,
+// keeping the microseconds Slack uses to tell messages in the same second
+// apart. Empty input stays empty and anything unparseable is kept as is.
+func newTimestamp(ts string, loc *time.Location) Timestamp {
+	if ts == "" {
+		return ""
+	}
+	t, err := parseSlackTime(ts)
+	if err != nil {
+		return Timestamp(ts)
+	}
+	return Timestamp(t.In(loc).Format(time.RFC3339Nano))
+}
+
+// parseSlackTime converts a Slack timestamp (e.g. "1234567890.123456") to a
+// time, keeping its fractional seconds.
+func parseSlackTime(ts string) (time.Time, error) {
+	secPart, fracPart, _ := strings.Cut(ts, ".")
+	sec, err := strconv.ParseInt(secPart, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var nsec int64
+	if fracPart != "" {
+		fracPart = (fracPart + "000000000")[:9]
+		if nsec, err = strconv.ParseInt(fracPart, 10, 64); err != nil {
+			return time.Time{}, err
+		}
+	}
+	return time.Unix(sec, nsec), nil
+}
+
+// formatSlackTimestamp converts a Slack timestamp (e.g. "1234567890.123456") to ISO 8601
+// in loc.
+func formatSlackTimestamp(ts string, loc *time.Location) string {
 	if ts == "" {
 		return ""
 	}
 	var sec int64
 	fmt.Sscanf(ts, "%d", &sec)
-	return time.Unix(sec, 0).UTC().Format(time.RFC3339)
+	return time.Unix(sec, 0).In(loc).Format(time.RFC3339)
 }
 
+// reFileNotice matches the text Slack generates for file_share and file_comment
+// messages (e.g. "<@U123> uploaded a file: <https://...|report.pdf>").
+var reFileNotice = regexp.MustCompile(`^<@[A-Z0-9]+(?:\|[^>]*)?> (?:uploaded a file:|commented on .*file) <[^>]*>:?`)
+
+// applyFileSubtype populates SubType and Files for file_share and file_comment
+// messages, replacing Slack's auto-generated notice text with the user's own
+// comment (or nothing, if there isn't one).
+func applyFileSubtype(info *MessageInfo, msg slack.Message) {
+	if msg.SubType != slack.MsgSubTypeFileShare && msg.SubType != slack.MsgSubTypeFileComment {
+		return
+	}
+
+	info.SubType = msg.SubType
+	for _, f := range msg.Files {
+		info.Files = append(info.Files, FileInfo{
+			ID:        f.ID,
+			Name:      f.Name,
+			Title:     f.Title,
+			Filetype:  f.Filetype,
+			Bytes:     f.Size,
+			Permalink: f.Permalink,
+		})
+	}
+
+	if msg.SubType == slack.MsgSubTypeFileComment && msg.Comment != nil {
+		info.Text = msg.Comment.Comment
+		return
+	}
+	info.Text = strings.TrimSpace(reFileNotice.ReplaceAllString(info.Text, ""))
+}
+
+// extractSharedMessage returns the first shared-message attachment on msg, or nil.
+// Shared messages are attachments that link back to the original via from_url.
+func extractSharedMessage(msg slack.Message) *SharedMessage {
+	for _, att := range msg.Attachments {
+		if att.FromURL == "" || att.Ts.String() == "" {
+			continue
+		}
+		return &SharedMessage{
+			Channel:   channelFromArchiveURL(att.FromURL),
+			User:      att.AuthorID,
+			Text:      att.Text,
+			Timestamp: att.Ts.String(),
+		}
+	}
+	return nil
+}
+
+// channelFromArchiveURL extracts the channel ID from a Slack archive URL
+// (e.g. https://example.slack.com/archives/C1234567890/p1234567890123456).
+func channelFromArchiveURL(u string) string {
+	_, rest, ok := strings.Cut(u, "/archives/")
+	if !ok {
+		return ""
+	}
+	channel, _, _ := strings.Cut(rest, "/")
+	return channel
+}
+
+// userLookupConcurrency bounds concurrent users.info calls made by userNameCache.Prefetch
+const userLookupConcurrency = 5
+
 // userNameCache provides lazy, cached user-name lookups within a single tool call.
 type userNameCache struct {
 	svc   *Service
 	ctx   context.Context
+	mu    sync.Mutex
 	cache map[string]string
 }
 
@@ -49,13 +204,97 @@ func (u *userNameCache) Get(userID string) string {
 	if userID == "" {
 		return ""
 	}
-	if name, ok := u.cache[userID]; ok {
+	u.mu.Lock()
+	name, ok := u.cache[userID]
+	u.mu.Unlock()
+	if ok {
 		return name
 	}
 	user, err := u.svc.api.GetUserInfoContext(u.ctx, userID)
 	if err == nil {
+		u.mu.Lock()
 		u.cache[userID] = user.Name
+		u.mu.Unlock()
 		return user.Name
 	}
 	return ""
 }
+
+// Author returns the name of a message's author. Bot posts usually have no
+// user, so they are named from the message's username override, its bot
+// profile, or bots.info, in that order.
+func (u *userNameCache) Author(msg slack.Message) string {
+	if msg.User != "" {
+		return u.Get(msg.User)
+	}
+	if msg.Username != "" {
+		return msg.Username
+	}
+	if msg.BotProfile != nil && msg.BotProfile.Name != "" {
+		return msg.BotProfile.Name
+	}
+	if msg.BotID == "" {
+		return ""
+	}
+
+	// Bot IDs (B...) never collide with user IDs, so they share the cache
+	u.mu.Lock()
+	name, ok := u.cache[msg.BotID]
+	u.mu.Unlock()
+	if ok {
+		return name
+	}
+	if bot, err := u.svc.api.GetBotInfoContext(u.ctx, slack.GetBotInfoParameters{Bot: msg.BotID}); err == nil {
+		name = bot.Name
+	}
+	u.mu.Lock()
+	u.cache[msg.BotID] = name
+	u.mu.Unlock()
+	return name
+}
+
+// isBotMessage reports whether msg was posted by a bot or app
+func isBotMessage(msg slack.Message) bool {
+	return msg.BotID != "" || msg.SubType == slack.MsgSubTypeBotMessage
+}
+
+// Prefetch resolves the given user IDs concurrently, with at most
+// userLookupConcurrency requests in flight. Only successful lookups are
+// cached; an ID that fails here is looked up again by a later Get.
+func (u *userNameCache) Prefetch(userIDs []string) {
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, userLookupConcurrency)
+	seen := make(map[string]bool)
+
+	for _, id := range userIDs {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		u.mu.Lock()
+		_, cached := u.cache[id]
+		u.mu.Unlock()
+		if cached {
+			continue
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			user, err := u.svc.api.GetUserInfoContext(u.ctx, id)
+			if err != nil {
+				u.svc.logger.Debug("Failed to prefetch user",
+					zap.String("user", id),
+					zap.Error(err))
+				return
+			}
+			u.mu.Lock()
+			u.cache[id] = user.Name
+			u.mu.Unlock()
+		}(id)
+	}
+	wg.Wait()
+}
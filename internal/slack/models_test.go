@@ -0,0 +1,62 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
+	"go.uber.org/mock/gomock"
+)
+
+func TestNewTimestamp(t *testing.T) {
+	chicago, err := time.LoadLocation("America/Chicago")
+	if err != nil {
+		t.Fatalf("LoadLocation failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		ts   string
+		loc  *time.Location
+		want Timestamp
+	}{
+		{"slack timestamp", "1234567890.123456", time.UTC, "2009-02-13T23:31:30.123456Z"},
+		{"whole seconds", "1234567890.000000", time.UTC, "2009-02-13T23:31:30Z"},
+		{"no fraction", "1234567890", time.UTC, "2009-02-13T23:31:30Z"},
+		{"display zone", "1234567890.123456", chicago, "2009-02-13T17:31:30.123456-06:00"},
+		{"unparseable", "soon", time.UTC, "soon"},
+		{"empty", "", time.UTC, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newTimestamp(tt.ts, tt.loc); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserNameCache_PrefetchDoesNotCacheFailures(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	api := NewMockSlackAPI(ctrl)
+
+	gomock.InOrder(
+		api.EXPECT().GetUserInfoContext(gomock.Any(), "U1").Return(nil, errors.New("fatal_error")),
+		api.EXPECT().GetUserInfoContext(gomock.Any(), "U1").Return(&slack.User{ID: "U1", Name: "alice"}, nil),
+	)
+	api.EXPECT().GetUserInfoContext(gomock.Any(), "U2").Return(&slack.User{ID: "U2", Name: "bob"}, nil)
+
+	svc := newServiceWithIndex(api, nil, nil, nil)
+	names := svc.newUserNameCache(context.Background())
+	names.Prefetch([]string{"U1", "U2"})
+
+	if got := names.Get("U1"); got != "alice" {
+		t.Errorf("Get(U1): got %q, want %q", got, "alice")
+	}
+	if got := names.Get("U2"); got != "bob" {
+		t.Errorf("Get(U2): got %q, want %q", got, "bob")
+	}
+}
@@ -0,0 +1,22 @@
+package slack
+
+import "context"
+
+// ProgressFunc receives progress updates from long-running tools. progress
+// is a running count that increases with each call.
+type ProgressFunc func(message string, progress float64)
+
+type progressKey struct{}
+
+// WithProgress returns a context whose tool calls report progress to fn.
+// Tools called without it report nothing.
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// reportProgress sends an update to the ProgressFunc in ctx, if any
+func reportProgress(ctx context.Context, message string, progress float64) {
+	if fn, ok := ctx.Value(progressKey{}).(ProgressFunc); ok && fn != nil {
+		fn(message, progress)
+	}
+}
@@ -2,8 +2,10 @@ package slack
 
 import (
 	"bufio"
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -25,36 +27,169 @@ func (w *FileResponseWriter) Dir() string {
 	return w.dir
 }
 
+// Read returns the content of a file in Dir(). Names containing path
+// separators are rejected so callers cannot escape the directory.
+func (w *FileResponseWriter) Read(name string) ([]byte, error) {
+	if !validResponseName(name) {
+		return nil, fmt.Errorf("invalid response name %q", name)
+	}
+	return os.ReadFile(filepath.Join(w.dir, name))
+}
+
+// Open opens a file in Dir() for streaming, with the same name checks as Read
+func (w *FileResponseWriter) Open(name string) (io.ReadCloser, error) {
+	if !validResponseName(name) {
+		return nil, fmt.Errorf("invalid response name %q", name)
+	}
+	return os.Open(filepath.Join(w.dir, name))
+}
+
+// validResponseName reports whether name is a plain, non-hidden file name
+func validResponseName(name string) bool {
+	return name != "" && name == filepath.Base(name) && !strings.HasPrefix(name, ".")
+}
+
+// Cleanup deletes files in Dir() last modified more than maxAge ago and
+// returns how many were removed. Subdirectories are left alone.
+func (w *FileResponseWriter) Cleanup(maxAge time.Duration) (int, error) {
+	entries, err := os.ReadDir(w.dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read responses directory: %w", err)
+	}
+
+	cutoff := time.Now().Add(-maxAge)
+	removed := 0
+	for _, e := range entries {
+		if !e.Type().IsRegular() {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		if info.ModTime().Before(cutoff) {
+			if err := os.Remove(filepath.Join(w.dir, e.Name())); err != nil && !os.IsNotExist(err) {
+				return removed, fmt.Errorf("failed to remove %s: %w", e.Name(), err)
+			}
+			removed++
+		}
+	}
+	return removed, nil
+}
+
 // WriteJSON marshals data to JSON and writes it to a timestamped file
 func (w *FileResponseWriter) WriteJSON(name string, data any) (FileRef, error) {
 	filename := fmt.Sprintf("%s-%d.json", name, time.Now().UnixNano())
+	return w.writeFileAtomic(filename, 1, func(f io.Writer) error {
+		return encodeJSON(f, data)
+	})
+}
+
+// WriteJSONGzip marshals data to JSON and writes it to a timestamped, gzip-compressed file
+func (w *FileResponseWriter) WriteJSONGzip(name string, data any) (FileRef, error) {
+	filename := fmt.Sprintf("%s-%d.json.gz", name, time.Now().UnixNano())
+	return w.writeFileAtomic(filename, 1, func(f io.Writer) error {
+		return encodeJSONGzip(f, data)
+	})
+}
+
+// encodeJSON writes data as indented JSON
+func encodeJSON(w io.Writer, data any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(data); err != nil {
+		return fmt.Errorf("failed to write data: %w", err)
+	}
+	return nil
+}
+
+// encodeJSONGzip writes data as compact, gzip-compressed JSON
+func encodeJSONGzip(w io.Writer, data any) error {
+	gz := gzip.NewWriter(w)
+	if err := json.NewEncoder(gz).Encode(data); err != nil {
+		return fmt.Errorf("failed to write data: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to flush gzip stream: %w", err)
+	}
+	return nil
+}
+
+// countLines counts lines in text, including a final line without a trailing newline
+func countLines(content string) int {
+	if content == "" {
+		return 0
+	}
+	lines := strings.Count(content, "\n")
+	if !strings.HasSuffix(content, "\n") {
+		lines++
+	}
+	return lines
+}
+
+// writeFileAtomic writes a file in Dir() via a temp file that is renamed into
+// place only after writeFn succeeds, so readers never see a partial file.
+func (w *FileResponseWriter) writeFileAtomic(filename string, lines int, writeFn func(f io.Writer) error) (_ FileRef, err error) {
 	filePath := filepath.Join(w.dir, filename)
 
-	file, err := os.Create(filePath)
+	file, err := os.CreateTemp(w.dir, filename+".tmp-*")
 	if err != nil {
 		return FileRef{}, fmt.Errorf("failed to create file: %w", err)
 	}
-	defer file.Close()
+	defer func() {
+		file.Close()
+		if err != nil {
+			os.Remove(file.Name())
+		}
+	}()
 
-	enc := json.NewEncoder(file)
-	enc.SetIndent("", "  ")
-	if err := enc.Encode(data); err != nil {
-		return FileRef{}, fmt.Errorf("failed to write data: %w", err)
+	if err := writeFn(file); err != nil {
+		return FileRef{}, err
 	}
 
-	fi, err := file.Stat()
+	size, err := commitTempFile(file, filePath)
 	if err != nil {
-		return FileRef{}, fmt.Errorf("failed to stat file: %w", err)
+		return FileRef{}, err
 	}
 
 	return FileRef{
 		Path:  filePath,
 		Name:  filename,
-		Bytes: fi.Size(),
-		Lines: 1,
+		URI:   ResponseURIPrefix + filename,
+		Bytes: size,
+		Lines: lines,
 	}, nil
 }
 
+// writeJSONList writes items as a JSON array, optionally gzip-compressed and split
+// into numbered chunk files of at most chunkSize items each. A chunkSize of zero
+// or less writes a single file.
+func writeJSONList[T any](w ResponseWriter, name string, items []T, chunkSize int, compress bool) ([]FileRef, error) {
+	write := w.WriteJSON
+	if compress {
+		write = w.WriteJSONGzip
+	}
+
+	if chunkSize <= 0 || len(items) <= chunkSize {
+		ref, err := write(name, items)
+		if err != nil {
+			return nil, err
+		}
+		return []FileRef{ref}, nil
+	}
+
+	refs := make([]FileRef, 0, (len(items)+chunkSize-1)/chunkSize)
+	for start := 0; start < len(items); start += chunkSize {
+		end := min(start+chunkSize, len(items))
+		ref, err := write(fmt.Sprintf("%s-part%03d", name, len(refs)+1), items[start:end])
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, ref)
+	}
+	return refs, nil
+}
+
 // jsonLineWriter implements JSONLineWriter for streaming writes directly to disk
 type jsonLineWriter struct {
 	bw    *bufio.Writer
@@ -92,56 +227,49 @@ func (w *FileResponseWriter) WriteJSONLinesNamed(filename string, writeFn func(j
 // WriteText writes plain text content to a timestamped file
 func (w *FileResponseWriter) WriteText(name string, content string) (FileRef, error) {
 	filename := fmt.Sprintf("%s-%d.txt", name, time.Now().UnixNano())
-	filePath := filepath.Join(w.dir, filename)
-
-	if err := os.WriteFile(filePath, []byte(content), 0o644); err != nil {
-		return FileRef{}, fmt.Errorf("failed to write file: %w", err)
-	}
-
-	lines := 0
-	if content != "" {
-		lines = strings.Count(content, "\n")
-		if !strings.HasSuffix(content, "\n") {
-			lines++
+	return w.writeFileAtomic(filename, countLines(content), func(f io.Writer) error {
+		if _, err := io.WriteString(f, content); err != nil {
+			return fmt.Errorf("failed to write file: %w", err)
 		}
-	}
-
-	return FileRef{
-		Path:  filePath,
-		Name:  filename,
-		Bytes: int64(len(content)),
-		Lines: lines,
-	}, nil
+		return nil
+	})
 }
 
 func (w *FileResponseWriter) writeJSONLinesFile(filename string, writeFn func(jw JSONLineWriter) error) (FileRef, error) {
-	filePath := filepath.Join(w.dir, filename)
-
-	file, err := os.Create(filePath)
+	var jw *jsonLineWriter
+	ref, err := w.writeFileAtomic(filename, 0, func(f io.Writer) error {
+		jw = &jsonLineWriter{bw: bufio.NewWriter(f)}
+		if err := writeFn(jw); err != nil {
+			return err
+		}
+		if err := jw.bw.Flush(); err != nil {
+			return fmt.Errorf("failed to flush buffer: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return FileRef{}, fmt.Errorf("failed to create file: %w", err)
-	}
-	defer file.Close()
-
-	jw := &jsonLineWriter{bw: bufio.NewWriter(file)}
-
-	if err := writeFn(jw); err != nil {
 		return FileRef{}, err
 	}
+	ref.Lines = jw.lines
+	return ref, nil
+}
 
-	if err := jw.bw.Flush(); err != nil {
-		return FileRef{}, fmt.Errorf("failed to flush buffer: %w", err)
+// commitTempFile closes a fully written temp file and atomically renames it
+// to path, returning its size. Temp files are created owner-only, so the
+// mode is widened to match files written with os.Create.
+func commitTempFile(file *os.File, path string) (int64, error) {
+	if err := file.Chmod(0o644); err != nil {
+		return 0, fmt.Errorf("failed to set file mode: %w", err)
 	}
-
 	fi, err := file.Stat()
 	if err != nil {
-		return FileRef{}, fmt.Errorf("failed to stat file: %w", err)
+		return 0, fmt.Errorf("failed to stat file: %w", err)
 	}
-
-	return FileRef{
-		Path:  filePath,
-		Name:  filename,
-		Bytes: fi.Size(),
-		Lines: jw.lines,
-	}, nil
+	if err := file.Close(); err != nil {
+		return 0, fmt.Errorf("failed to close file: %w", err)
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return 0, fmt.Errorf("failed to rename file: %w", err)
+	}
+	return fi.Size(), nil
 }
@@ -1,12 +1,16 @@
 package slack
 
 import (
+	"compress/gzip"
 	"encoding/json"
 	"errors"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestWriteJSONLines_Basic(t *testing.T) {
@@ -123,6 +127,34 @@ func TestWriteJSONLines_WriterError(t *testing.T) {
 	}
 }
 
+func TestWriteJSONLinesNamed_ErrorLeavesNoPartialFile(t *testing.T) {
+	dir := t.TempDir()
+	w := NewFileResponseWriter(dir)
+
+	wantErr := errors.New("connection reset")
+	_, err := w.WriteJSONLinesNamed("partial.jsonl", func(jw JSONLineWriter) error {
+		if err := jw.WriteLine(map[string]string{"text": "first"}); err != nil {
+			return err
+		}
+		return wantErr
+	})
+	if err != wantErr {
+		t.Fatalf("Error: got %v, want %v", err, wantErr)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "partial.jsonl")); !os.IsNotExist(err) {
+		t.Errorf("final file should not exist, stat error: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read dir: %v", err)
+	}
+	for _, e := range entries {
+		t.Errorf("temp file not cleaned up: %s", e.Name())
+	}
+}
+
 func TestWriteJSONLines_MarshalError(t *testing.T) {
 	dir, err := os.MkdirTemp("", "response-writer-test-*")
 	if err != nil {
@@ -284,3 +316,189 @@ func TestWriteJSON(t *testing.T) {
 		t.Errorf("Data: got %+v, want {Name:test Value:42}", result)
 	}
 }
+
+func TestWriteJSON_ErrorLeavesNoPartialFile(t *testing.T) {
+	dir := t.TempDir()
+	w := NewFileResponseWriter(dir)
+
+	// Encoding fails on the channel value
+	_, err := w.WriteJSON("broken", map[string]any{"a": 1, "b": make(chan int)})
+	if err == nil {
+		t.Fatal("Expected encode error, got nil")
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Failed to read dir: %v", err)
+	}
+	for _, e := range entries {
+		t.Errorf("partial file left behind: %s", e.Name())
+	}
+}
+
+func TestWriteJSONGzip(t *testing.T) {
+	dir := t.TempDir()
+	w := NewFileResponseWriter(dir)
+
+	want := []string{"alpha", "beta", "gamma"}
+	ref, err := w.WriteJSONGzip("test", want)
+	if err != nil {
+		t.Fatalf("WriteJSONGzip failed: %v", err)
+	}
+
+	if !strings.HasSuffix(ref.Name, ".json.gz") {
+		t.Errorf("Name: got %q, want .json.gz suffix", ref.Name)
+	}
+
+	got := readGzipJSON[[]string](t, ref.Path)
+	if !slices.Equal(got, want) {
+		t.Errorf("Data: got %v, want %v", got, want)
+	}
+}
+
+func TestWriteJSONList_Chunks(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+
+	tests := []struct {
+		name       string
+		chunkSize  int
+		compress   bool
+		wantChunks [][]int
+	}{
+		{"no chunking", 0, false, [][]int{{1, 2, 3, 4, 5}}},
+		{"chunk larger than items", 10, false, [][]int{{1, 2, 3, 4, 5}}},
+		{"uneven chunks", 2, false, [][]int{{1, 2}, {3, 4}, {5}}},
+		{"exact chunks compressed", 5, true, [][]int{{1, 2, 3, 4, 5}}},
+		{"uneven chunks compressed", 3, true, [][]int{{1, 2, 3}, {4, 5}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := NewFileResponseWriter(t.TempDir())
+
+			refs, err := writeJSONList(w, "items", items, tt.chunkSize, tt.compress)
+			if err != nil {
+				t.Fatalf("writeJSONList failed: %v", err)
+			}
+
+			if len(refs) != len(tt.wantChunks) {
+				t.Fatalf("len(refs): got %d, want %d", len(refs), len(tt.wantChunks))
+			}
+
+			var all []int
+			for i, ref := range refs {
+				var got []int
+				if tt.compress {
+					got = readGzipJSON[[]int](t, ref.Path)
+				} else {
+					data, err := os.ReadFile(ref.Path)
+					if err != nil {
+						t.Fatalf("Failed to read file: %v", err)
+					}
+					if err := json.Unmarshal(data, &got); err != nil {
+						t.Fatalf("Failed to unmarshal: %v", err)
+					}
+				}
+				if !slices.Equal(got, tt.wantChunks[i]) {
+					t.Errorf("chunk %d: got %v, want %v", i, got, tt.wantChunks[i])
+				}
+				all = append(all, got...)
+			}
+
+			if !slices.Equal(all, items) {
+				t.Errorf("all chunks: got %v, want %v", all, items)
+			}
+		})
+	}
+}
+
+func readGzipJSON[T any](t *testing.T, path string) T {
+	t.Helper()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open file: %v", err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatalf("Failed to open gzip stream: %v", err)
+	}
+	defer gz.Close()
+
+	var v T
+	if err := json.NewDecoder(gz).Decode(&v); err != nil {
+		t.Fatalf("Failed to decode: %v", err)
+	}
+	return v
+}
+
+func TestCleanup(t *testing.T) {
+	dir := t.TempDir()
+	w := NewFileResponseWriter(dir)
+
+	oldPath := filepath.Join(dir, "old.json")
+	freshPath := filepath.Join(dir, "fresh.json")
+	for _, p := range []string{oldPath, freshPath} {
+		if err := os.WriteFile(p, []byte("{}"), 0o644); err != nil {
+			t.Fatalf("Failed to write %s: %v", p, err)
+		}
+	}
+	past := time.Now().Add(-48 * time.Hour)
+	if err := os.Chtimes(oldPath, past, past); err != nil {
+		t.Fatalf("Failed to age file: %v", err)
+	}
+
+	removed, err := w.Cleanup(24 * time.Hour)
+	if err != nil {
+		t.Fatalf("Cleanup failed: %v", err)
+	}
+	if removed != 1 {
+		t.Errorf("removed: got %d, want 1", removed)
+	}
+
+	if _, err := os.Stat(oldPath); !os.IsNotExist(err) {
+		t.Errorf("old file should be removed, stat error: %v", err)
+	}
+	if _, err := os.Stat(freshPath); err != nil {
+		t.Errorf("fresh file should remain: %v", err)
+	}
+}
+
+func TestFileResponseWriter_Read(t *testing.T) {
+	dir := t.TempDir()
+	w := NewFileResponseWriter(dir)
+
+	ref, err := w.WriteText("note", "hello")
+	if err != nil {
+		t.Fatalf("WriteText failed: %v", err)
+	}
+	if ref.URI != ResponseURIPrefix+ref.Name {
+		t.Errorf("URI: got %q, want %q", ref.URI, ResponseURIPrefix+ref.Name)
+	}
+
+	data, err := w.Read(ref.Name)
+	if err != nil || string(data) != "hello" {
+		t.Errorf("Read: got %q, %v; want hello", data, err)
+	}
+
+	rc, err := w.Open(ref.Name)
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	data, err = io.ReadAll(rc)
+	rc.Close()
+	if err != nil || string(data) != "hello" {
+		t.Errorf("Open: got %q, %v; want hello", data, err)
+	}
+
+	for _, name := range []string{"", "../secret", "sub/file.json", ".hidden"} {
+		if _, err := w.Read(name); err == nil {
+			t.Errorf("Read(%q): got nil error, want invalid name", name)
+		}
+		if _, err := w.Open(name); err == nil {
+			t.Errorf("Open(%q): got nil error, want invalid name", name)
+		}
+	}
+}
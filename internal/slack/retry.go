@@ -3,37 +3,189 @@ package slack
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
 	"time"
 
 	"github.com/slack-go/slack"
 	"go.uber.org/zap"
+	"golang.org/x/time/rate"
 )
 
-// withRetry executes fn and automatically retries on Slack rate limit errors.
-// The fn closure should perform the API call and return any error.
+const (
+	defaultTransientAttempts = 3
+	defaultBaseDelay         = 500 * time.Millisecond
+	defaultMaxDelay          = 8 * time.Second
+	defaultAttemptTimeout    = 60 * time.Second
+)
+
+// RetryConfig bounds how withRetry retries failed API calls.
+// Zero values select the defaults: rate limit errors are retried indefinitely
+// after the server-provided Retry-After delay, and transient failures (5xx
+// responses and network timeouts) are attempted up to 3 times with exponential
+// backoff from 500ms up to 8s. Each attempt is cut off after 60 seconds.
+type RetryConfig struct {
+	// MaxAttempts caps the total number of calls, including rate-limited ones
+	MaxAttempts int
+	// BaseDelay is the backoff before the first transient retry
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff between transient retries
+	MaxDelay time.Duration
+	// AttemptTimeout bounds each attempt, including reading the response
+	// body; a negative value disables it. An attempt that times out is
+	// retried as a transient failure.
+	AttemptTimeout time.Duration
+}
+
+// transientAttempts returns the attempt limit for transient failures
+func (rc RetryConfig) transientAttempts() int {
+	if rc.MaxAttempts > 0 {
+		return rc.MaxAttempts
+	}
+	return defaultTransientAttempts
+}
+
+// attemptTimeout returns the per-attempt timeout, or 0 if there is none
+func (rc RetryConfig) attemptTimeout() time.Duration {
+	switch {
+	case rc.AttemptTimeout < 0:
+		return 0
+	case rc.AttemptTimeout == 0:
+		return defaultAttemptTimeout
+	}
+	return rc.AttemptTimeout
+}
+
+// backoff returns the delay before the given retry attempt (1-based),
+// doubling from BaseDelay up to MaxDelay with up to 50% jitter.
+func (rc RetryConfig) backoff(attempt int) time.Duration {
+	base, maxDelay := rc.BaseDelay, rc.MaxDelay
+	if base <= 0 {
+		base = defaultBaseDelay
+	}
+	if maxDelay <= 0 {
+		maxDelay = defaultMaxDelay
+	}
+
+	delay := base << (attempt - 1)
+	if delay <= 0 || delay > maxDelay {
+		delay = maxDelay
+	}
+	half := delay / 2
+	if half <= 0 {
+		return delay
+	}
+	return half + rand.N(half)
+}
+
+// isTransientError reports whether err is a server error or network timeout worth retrying
+func isTransientError(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var statusErr slack.StatusCodeError
+	if errors.As(err, &statusErr) {
+		return statusErr.Code >= 500
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return netErr.Timeout()
+	}
+
+	return false
+}
+
+// newLimiter returns a token-bucket limiter allowing requestsPerSecond calls
+// with a burst of one second's worth, or nil (unlimited) if requestsPerSecond <= 0.
+func newLimiter(requestsPerSecond float64) *rate.Limiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(requestsPerSecond), max(1, int(requestsPerSecond)))
+}
+
+// withRetry executes fn and automatically retries on Slack rate limit errors
+// and transient failures, as bounded by cfg. If limiter is non-nil, a token is
+// acquired before every attempt.
+// The fn closure should perform the API call with the context it is given,
+// which carries the attempt timeout, and return any error.
 // Results should be captured in variables in the outer scope.
-func withRetry(ctx context.Context, logger *zap.Logger, fn func() error) error {
+func withRetry(ctx context.Context, logger *zap.Logger, cfg RetryConfig, limiter *rate.Limiter, fn func(context.Context) error) error {
+	attempts := 0
+	transient := 0
 	for {
-		err := fn()
+		if limiter != nil {
+			if err := limiter.Wait(ctx); err != nil {
+				logger.Debug("Context cancelled while waiting for rate limiter")
+				return err
+			}
+		}
+
+		err := attempt(ctx, cfg.attemptTimeout(), fn)
 		if err == nil {
 			return nil
 		}
+		attempts++
 
+		var wait time.Duration
 		var rateLimitErr *slack.RateLimitedError
 		if errors.As(err, &rateLimitErr) {
+			if cfg.MaxAttempts > 0 && attempts >= cfg.MaxAttempts {
+				logger.Warn("Rate limit hit, retries exhausted",
+					zap.Int("attempts", attempts))
+				return err
+			}
 			logger.Warn("Rate limit hit, waiting before retry",
 				zap.Duration("retry_after", rateLimitErr.RetryAfter))
-			select {
-			case <-time.After(rateLimitErr.RetryAfter):
-				logger.Info("Retrying after rate limit wait")
-				continue
-			case <-ctx.Done():
-				logger.Debug("Context cancelled during rate limit wait")
-				return ctx.Err()
+			wait = rateLimitErr.RetryAfter
+		} else if isTransientError(err) || errors.Is(err, errAttemptTimeout) {
+			transient++
+			if transient >= cfg.transientAttempts() || (cfg.MaxAttempts > 0 && attempts >= cfg.MaxAttempts) {
+				logger.Warn("Transient error, retries exhausted",
+					zap.Int("attempts", attempts),
+					zap.Error(err))
+				return err
+			}
+			wait = cfg.backoff(transient)
+			logger.Warn("Transient error, waiting before retry",
+				zap.Int("attempt", attempts),
+				zap.Duration("backoff", wait),
+				zap.Error(err))
+		} else {
+			if errors.Is(err, context.Canceled) {
+				logger.Debug("Context cancelled during API call")
 			}
-		} else if errors.Is(err, context.Canceled) {
-			logger.Debug("Context cancelled during API call")
+			return err
 		}
-		return err
+
+		select {
+		case <-time.After(wait):
+			logger.Info("Retrying after wait")
+		case <-ctx.Done():
+			logger.Debug("Context cancelled during retry wait")
+			return ctx.Err()
+		}
+	}
+}
+
+// errAttemptTimeout marks an attempt cut off by RetryConfig.AttemptTimeout
+// while the caller's context was still live.
+var errAttemptTimeout = errors.New("attempt timed out")
+
+// attempt calls fn once with a context bounded by timeout, if there is one
+func attempt(ctx context.Context, timeout time.Duration, fn func(context.Context) error) error {
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := fn(attemptCtx)
+	if err != nil && errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+		return fmt.Errorf("%w after %v: %w", errAttemptTimeout, timeout, err)
 	}
+	return err
 }
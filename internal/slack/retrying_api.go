@@ -0,0 +1,195 @@
+package slack
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+// retryingAPI wraps a SlackAPI so that every call waits on the shared rate
+// limiter and is retried by withRetry. NewService installs it, so tools call
+// c.api directly and get the same pacing and retry behaviour everywhere.
+type retryingAPI struct {
+	api     SlackAPI
+	logger  *zap.Logger
+	retry   RetryConfig
+	limiter *rate.Limiter
+}
+
+var _ SlackAPI = (*retryingAPI)(nil)
+
+func (r *retryingAPI) do(ctx context.Context, fn func(context.Context) error) error {
+	return withRetry(ctx, r.logger, r.retry, r.limiter, fn)
+}
+
+func (r *retryingAPI) GetConversationsContext(ctx context.Context, params *slack.GetConversationsParameters) (channels []slack.Channel, cursor string, err error) {
+	err = r.do(ctx, func(ctx context.Context) error {
+		var e error
+		channels, cursor, e = r.api.GetConversationsContext(ctx, params)
+		return e
+	})
+	return channels, cursor, err
+}
+
+func (r *retryingAPI) GetConversationInfoContext(ctx context.Context, input *slack.GetConversationInfoInput) (ch *slack.Channel, err error) {
+	err = r.do(ctx, func(ctx context.Context) error {
+		var e error
+		ch, e = r.api.GetConversationInfoContext(ctx, input)
+		return e
+	})
+	return ch, err
+}
+
+func (r *retryingAPI) GetConversationHistoryContext(ctx context.Context, params *slack.GetConversationHistoryParameters) (history *slack.GetConversationHistoryResponse, err error) {
+	err = r.do(ctx, func(ctx context.Context) error {
+		var e error
+		history, e = r.api.GetConversationHistoryContext(ctx, params)
+		return e
+	})
+	return history, err
+}
+
+func (r *retryingAPI) GetConversationRepliesContext(ctx context.Context, params *slack.GetConversationRepliesParameters) (msgs []slack.Message, hasMore bool, cursor string, err error) {
+	err = r.do(ctx, func(ctx context.Context) error {
+		var e error
+		msgs, hasMore, cursor, e = r.api.GetConversationRepliesContext(ctx, params)
+		return e
+	})
+	return msgs, hasMore, cursor, err
+}
+
+func (r *retryingAPI) GetUserInfoContext(ctx context.Context, user string) (u *slack.User, err error) {
+	err = r.do(ctx, func(ctx context.Context) error {
+		var e error
+		u, e = r.api.GetUserInfoContext(ctx, user)
+		return e
+	})
+	return u, err
+}
+
+func (r *retryingAPI) GetUserByEmailContext(ctx context.Context, email string) (u *slack.User, err error) {
+	err = r.do(ctx, func(ctx context.Context) error {
+		var e error
+		u, e = r.api.GetUserByEmailContext(ctx, email)
+		return e
+	})
+	return u, err
+}
+
+func (r *retryingAPI) SearchMessagesContext(ctx context.Context, query string, params slack.SearchParameters) (result *slack.SearchMessages, err error) {
+	err = r.do(ctx, func(ctx context.Context) error {
+		var e error
+		result, e = r.api.SearchMessagesContext(ctx, query, params)
+		return e
+	})
+	return result, err
+}
+
+func (r *retryingAPI) GetPermalinkContext(ctx context.Context, params *slack.PermalinkParameters) (permalink string, err error) {
+	err = r.do(ctx, func(ctx context.Context) error {
+		var e error
+		permalink, e = r.api.GetPermalinkContext(ctx, params)
+		return e
+	})
+	return permalink, err
+}
+
+func (r *retryingAPI) GetFileInfoContext(ctx context.Context, fileID string, count int, page int) (file *slack.File, comments []slack.Comment, paging *slack.Paging, err error) {
+	err = r.do(ctx, func(ctx context.Context) error {
+		var e error
+		file, comments, paging, e = r.api.GetFileInfoContext(ctx, fileID, count, page)
+		return e
+	})
+	return file, comments, paging, err
+}
+
+// GetFileContext buffers each attempt so a download that fails part-way and
+// is retried doesn't leave a partial copy in writer.
+func (r *retryingAPI) GetFileContext(ctx context.Context, downloadURL string, writer io.Writer) error {
+	var buf bytes.Buffer
+	err := r.do(ctx, func(ctx context.Context) error {
+		buf.Reset()
+		return r.api.GetFileContext(ctx, downloadURL, &buf)
+	})
+	if err != nil {
+		return err
+	}
+	_, err = buf.WriteTo(writer)
+	return err
+}
+
+func (r *retryingAPI) GetFilesContext(ctx context.Context, params slack.GetFilesParameters) (files []slack.File, paging *slack.Paging, err error) {
+	err = r.do(ctx, func(ctx context.Context) error {
+		var e error
+		files, paging, e = r.api.GetFilesContext(ctx, params)
+		return e
+	})
+	return files, paging, err
+}
+
+func (r *retryingAPI) GetUsersInConversationContext(ctx context.Context, params *slack.GetUsersInConversationParameters) (members []string, cursor string, err error) {
+	err = r.do(ctx, func(ctx context.Context) error {
+		var e error
+		members, cursor, e = r.api.GetUsersInConversationContext(ctx, params)
+		return e
+	})
+	return members, cursor, err
+}
+
+func (r *retryingAPI) ListPinsContext(ctx context.Context, channel string) (items []slack.Item, paging *slack.Paging, err error) {
+	err = r.do(ctx, func(ctx context.Context) error {
+		var e error
+		items, paging, e = r.api.ListPinsContext(ctx, channel)
+		return e
+	})
+	return items, paging, err
+}
+
+func (r *retryingAPI) ListBookmarksContext(ctx context.Context, channelID string) (bookmarks []slack.Bookmark, err error) {
+	err = r.do(ctx, func(ctx context.Context) error {
+		var e error
+		bookmarks, e = r.api.ListBookmarksContext(ctx, channelID)
+		return e
+	})
+	return bookmarks, err
+}
+
+func (r *retryingAPI) AuthTestContext(ctx context.Context) (resp *slack.AuthTestResponse, err error) {
+	err = r.do(ctx, func(ctx context.Context) error {
+		var e error
+		resp, e = r.api.AuthTestContext(ctx)
+		return e
+	})
+	return resp, err
+}
+
+func (r *retryingAPI) GetScheduledMessagesContext(ctx context.Context, params *slack.GetScheduledMessagesParameters) (msgs []slack.ScheduledMessage, cursor string, err error) {
+	err = r.do(ctx, func(ctx context.Context) error {
+		var e error
+		msgs, cursor, e = r.api.GetScheduledMessagesContext(ctx, params)
+		return e
+	})
+	return msgs, cursor, err
+}
+
+func (r *retryingAPI) ListRemindersContext(ctx context.Context) (reminders []*slack.Reminder, err error) {
+	err = r.do(ctx, func(ctx context.Context) error {
+		var e error
+		reminders, e = r.api.ListRemindersContext(ctx)
+		return e
+	})
+	return reminders, err
+}
+
+func (r *retryingAPI) GetBotInfoContext(ctx context.Context, parameters slack.GetBotInfoParameters) (bot *slack.Bot, err error) {
+	err = r.do(ctx, func(ctx context.Context) error {
+		var e error
+		bot, e = r.api.GetBotInfoContext(ctx, parameters)
+		return e
+	})
+	return bot, err
+}
@@ -2,9 +2,15 @@ package slack
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
+	"net/netip"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/slack-go/slack"
 	"go.uber.org/zap"
@@ -24,12 +30,24 @@ type SlackAPI interface {
 	GetPermalinkContext(ctx context.Context, params *slack.PermalinkParameters) (string, error)
 	GetFileInfoContext(ctx context.Context, fileID string, count int, page int) (*slack.File, []slack.Comment, *slack.Paging, error)
 	GetFileContext(ctx context.Context, downloadURL string, writer io.Writer) error
+	GetFilesContext(ctx context.Context, params slack.GetFilesParameters) ([]slack.File, *slack.Paging, error)
+	GetUsersInConversationContext(ctx context.Context, params *slack.GetUsersInConversationParameters) ([]string, string, error)
+	ListPinsContext(ctx context.Context, channel string) ([]slack.Item, *slack.Paging, error)
+	ListBookmarksContext(ctx context.Context, channelID string) ([]slack.Bookmark, error)
+	AuthTestContext(ctx context.Context) (*slack.AuthTestResponse, error)
+	GetScheduledMessagesContext(ctx context.Context, params *slack.GetScheduledMessagesParameters) ([]slack.ScheduledMessage, string, error)
+	ListRemindersContext(ctx context.Context) ([]*slack.Reminder, error)
+	GetBotInfoContext(ctx context.Context, parameters slack.GetBotInfoParameters) (*slack.Bot, error)
 }
 
+// ResponseURIPrefix is the MCP resource URI prefix under which response files can be read
+const ResponseURIPrefix = "slack://responses/"
+
 // FileRef describes a file written by ResponseWriter
 type FileRef struct {
 	Path  string `json:"path"`
 	Name  string `json:"name"`
+	URI   string `json:"uri,omitempty"`
 	Bytes int64  `json:"bytes"`
 	Lines int    `json:"lines"`
 }
@@ -42,46 +60,170 @@ type JSONLineWriter interface {
 // ResponseWriter writes large response data to a file and returns a reference
 type ResponseWriter interface {
 	WriteJSON(name string, data any) (FileRef, error)
+	WriteJSONGzip(name string, data any) (FileRef, error)
 	WriteJSONLines(name string, writeFn func(w JSONLineWriter) error) (FileRef, error)
 	WriteJSONLinesNamed(filename string, writeFn func(w JSONLineWriter) error) (FileRef, error)
 	WriteText(name string, content string) (FileRef, error)
+	Read(name string) ([]byte, error)
+	Open(name string) (io.ReadCloser, error)
 	Dir() string
 }
 
+// Config holds tunable behavior for the Service. The zero value selects defaults.
+type Config struct {
+	Retry RetryConfig
+	// Timezone is an IANA zone name (e.g. "America/Chicago") for formatting timestamps.
+	// If empty, ResolveTimezone uses the authenticated user's time zone.
+	Timezone string
+	// RequestsPerSecond throttles API calls across all tools. Zero means unlimited.
+	RequestsPerSecond float64
+	// MaxInlineBytes is the largest JSON result that list and search tools
+	// return inline instead of writing to a file. Zero selects the default
+	// (8 KiB); a negative value always writes files.
+	MaxInlineBytes int
+	// ChannelInfoTTL is how long conversations.info results are reused.
+	// Zero selects the default (5 minutes); a negative value disables caching.
+	ChannelInfoTTL time.Duration
+	// MaxChannelPages bounds how many pages of conversations.list are scanned
+	// when a channel name is not in the index. Zero disables the scan, so
+	// unknown names fail immediately.
+	MaxChannelPages int
+	// LinkCheckTimeout bounds each HTTP request made by CheckLinks.
+	// Zero selects the default (10 seconds).
+	LinkCheckTimeout time.Duration
+	// ThreadPageSize is how many replies ExportChannel requests per page of
+	// conversations.replies. Zero selects the default (200).
+	ThreadPageSize int
+	// CheckpointDir is where ExportChannel saves progress so interrupted
+	// exports can be resumed. Empty disables checkpointing.
+	CheckpointDir string
+	// ExportDir is the only directory ExportChannel may write output_path
+	// files under. Empty disables output_path.
+	ExportDir string
+}
+
 type Service struct {
 	api       SlackAPI
 	index     *channelIndex
+	infoCache *channelInfoCache
 	logger    *zap.Logger
 	responses ResponseWriter
+
+	maxChannelPages  int
+	maxInlineBytes   int
+	linkCheckTimeout time.Duration
+	// linkAddressCheck decides which addresses CheckLinks may connect to;
+	// nil selects requirePublicAddress
+	linkAddressCheck func(netip.Addr) error
+	checkpointDir    string
+	exportDir        string
+	threadPageSize   int
+
+	// scans lets concurrent lookups of the same unindexed name share one
+	// directory scan, keyed by name and conversation types
+	scanMu sync.Mutex
+	scans  map[string]*channelScan
+
+	tzMu     sync.Mutex
+	timezone string
+	location atomic.Pointer[time.Location]
 }
 
+// defaultChannelInfoTTL is the channel info cache lifetime used when Config.ChannelInfoTTL is zero
+const defaultChannelInfoTTL = 5 * time.Minute
+
 // NewService creates a service-layer client with pre-built dependencies
-func NewService(api SlackAPI, logger *zap.Logger, responses ResponseWriter) *Service {
+func NewService(api SlackAPI, logger *zap.Logger, responses ResponseWriter, cfg Config) *Service {
+	var infoCache *channelInfoCache
+	switch {
+	case cfg.ChannelInfoTTL == 0:
+		infoCache = newChannelInfoCache(defaultChannelInfoTTL)
+	case cfg.ChannelInfoTTL > 0:
+		infoCache = newChannelInfoCache(cfg.ChannelInfoTTL)
+	}
+
 	return &Service{
-		api:       api,
+		api: &retryingAPI{
+			api:     api,
+			logger:  logger,
+			retry:   cfg.Retry,
+			limiter: newLimiter(cfg.RequestsPerSecond),
+		},
 		index:     newIndex(),
+		infoCache: infoCache,
 		logger:    logger,
 		responses: responses,
+		timezone:  cfg.Timezone,
+
+		maxChannelPages:  cfg.MaxChannelPages,
+		maxInlineBytes:   cfg.MaxInlineBytes,
+		linkCheckTimeout: cfg.LinkCheckTimeout,
+		checkpointDir:    cfg.CheckpointDir,
+		exportDir:        cfg.ExportDir,
+		threadPageSize:   cfg.ThreadPageSize,
+	}
+}
+
+// ReadResponse returns the content of a previously written response file by name
+func (c *Service) ReadResponse(name string) ([]byte, error) {
+	return c.responses.Read(name)
+}
+
+// defaultMaxInlineBytes is the inline result threshold used when Config.MaxInlineBytes is zero
+const defaultMaxInlineBytes = 8 * 1024
+
+// fitsInline reports whether data is small enough, once marshalled, to return
+// in the tool output rather than writing it to a file.
+func (c *Service) fitsInline(data any) bool {
+	limit := c.maxInlineBytes
+	if limit == 0 {
+		limit = defaultMaxInlineBytes
+	}
+	if limit < 0 {
+		return false
 	}
+	b, err := json.Marshal(data)
+	return err == nil && len(b) <= limit
 }
 
 // GetChannelID accepts either a channel name or ID and returns the channel ID
-func (c *Service) GetChannelID(channelOrName string) (string, error) {
+//
+// types lists the conversation types to search when the name is not already
+// indexed; it defaults to public and private channels. Group DM names
+// (mpdm-...) always include mpim. IDs, including D-prefixed DM IDs, are
+// returned as-is without consulting the index. A message permalink resolves
+// to the channel it points into.
+func (c *Service) GetChannelID(ctx context.Context, channelOrName string, types ...string) (string, error) {
 	if isChannelID(channelOrName) {
 		return channelOrName, nil
 	}
-	return c.findChannelID(channelOrName)
+	if isPermalink(channelOrName) {
+		channel, _, err := ParsePermalink(channelOrName)
+		return channel, err
+	}
+	return c.findChannelID(ctx, channelOrName, types)
 }
 
-// isChannelID checks if a string looks like a Slack channel ID
-// Channel IDs are uppercase alphanumeric strings starting with C, D, or G
-// and are typically 9-11 characters long
+// defaultChannelTypes are the conversation types searched when resolving a name
+var defaultChannelTypes = []string{"public_channel", "private_channel"}
+
+// messageChannelTypes are the conversation types searched by tools that read
+// messages, which work equally well on DMs and group DMs
+var messageChannelTypes = []string{"public_channel", "private_channel", "mpim", "im"}
+
+// isChannelID checks if a string looks like a Slack conversation ID.
+//
+// Conversation IDs are uppercase alphanumeric and start with C (channels,
+// including Enterprise Grid shared channels), D (DMs) or G (private channels
+// and group DMs created before 2021). They were 9 characters historically and
+// are 11 today; the upper bound leaves room for growth while rejecting
+// arbitrary upper-case words. W is deliberately not accepted: on Enterprise
+// Grid it prefixes user IDs (see isUserID), not conversations.
 func isChannelID(s string) bool {
-	if len(s) < 9 {
+	if len(s) < minChannelIDLen || len(s) > maxChannelIDLen {
 		return false
 	}
 
-	// Must start with C, D, or G
 	if s[0] != 'C' && s[0] != 'D' && s[0] != 'G' {
 		return false
 	}
@@ -96,6 +238,12 @@ func isChannelID(s string) bool {
 	return true
 }
 
+// Length window for conversation IDs accepted by isChannelID
+const (
+	minChannelIDLen = 9
+	maxChannelIDLen = 15
+)
+
 // listConversations wraps the Slack API call and feeds the channel index.
 func (c *Service) listConversations(ctx context.Context, params *slack.GetConversationsParameters) ([]slack.Channel, string, error) {
 	channels, cursor, err := c.api.GetConversationsContext(ctx, params)
@@ -129,28 +277,65 @@ func (c *Service) searchMessages(ctx context.Context, query string, params slack
 }
 
 // getConversationInfo wraps the Slack API call and feeds the channel index.
+// Results are reused from the channel info cache until they expire.
 func (c *Service) getConversationInfo(ctx context.Context, channelID string) (*slack.Channel, error) {
-	var ch *slack.Channel
-	err := withRetry(ctx, c.logger, func() error {
-		var e error
-		ch, e = c.api.GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{
-			ChannelID: channelID,
-		})
-		return e
+	if ch, ok := c.infoCache.Get(channelID); ok {
+		return ch, nil
+	}
+
+	ch, err := c.api.GetConversationInfoContext(ctx, &slack.GetConversationInfoInput{
+		ChannelID: channelID,
 	})
 	if err != nil {
 		return nil, err
 	}
 	c.index.Add([]slack.Channel{*ch})
+	c.infoCache.Put(*ch)
 	return ch, nil
 }
 
-// findChannelID looks up a channel name in the index
-func (c *Service) findChannelID(name string) (string, error) {
+// walkHistory pages through a channel's top-level messages between oldest and
+// latest (newest first), calling fn with each page.
+func (c *Service) walkHistory(ctx context.Context, channelID, oldest, latest string, fn func([]slack.Message)) error {
+	cursor := ""
+	for {
+		history, err := c.api.GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+			ChannelID: channelID,
+			Cursor:    cursor,
+			Oldest:    oldest,
+			Latest:    latest,
+			Limit:     200,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get history: %w", err)
+		}
+
+		fn(history.Messages)
+
+		if !history.HasMore || history.ResponseMetaData.NextCursor == "" {
+			return nil
+		}
+		cursor = history.ResponseMetaData.NextCursor
+	}
+}
+
+// findChannelID looks up a channel name in the index, falling back to a
+// bounded scan of the channel directory when MaxChannelPages is set.
+func (c *Service) findChannelID(ctx context.Context, name string, types []string) (string, error) {
 	name = strings.TrimPrefix(name, "#")
 
+	if len(types) == 0 {
+		types = defaultChannelTypes
+	}
+	if strings.HasPrefix(name, "mpdm-") && !slices.Contains(types, "mpim") {
+		types = append(slices.Clip(types), "mpim")
+	}
+
 	ch, ok := c.index.GetByName(name)
 	if !ok {
+		if c.maxChannelPages > 0 {
+			return c.sharedChannelScan(ctx, name, types)
+		}
 		return "", fmt.Errorf("channel %q not found in index (%d entries); use a channel ID or call slack_list_channels first", name, c.index.Size())
 	}
 
@@ -160,3 +345,123 @@ func (c *Service) findChannelID(name string) (string, error) {
 
 	return ch.ID, nil
 }
+
+// channelScan is a directory scan shared by every caller looking up the
+// same name. waiters and cancel are guarded by Service.scanMu; id and err
+// are set before done is closed.
+type channelScan struct {
+	done    chan struct{}
+	cancel  context.CancelFunc
+	waiters int
+	id      string
+	err     error
+}
+
+// sharedChannelScan runs scanChannelDirectory, joining a scan for the same
+// name that is already in flight instead of starting another. The scan
+// outlives the cancellation of any one caller, since others may be waiting
+// on it, but is cancelled once every caller has given up.
+func (c *Service) sharedChannelScan(ctx context.Context, name string, types []string) (string, error) {
+	key := strings.ToLower(name) + "|" + strings.Join(types, ",")
+
+	c.scanMu.Lock()
+	scan, ok := c.scans[key]
+	if !ok {
+		scanCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		scan = &channelScan{done: make(chan struct{}), cancel: cancel}
+		if c.scans == nil {
+			c.scans = make(map[string]*channelScan)
+		}
+		c.scans[key] = scan
+		go func() {
+			id, err := c.scanChannelDirectory(scanCtx, name, types)
+			cancel()
+			c.scanMu.Lock()
+			if c.scans[key] == scan {
+				delete(c.scans, key)
+			}
+			c.scanMu.Unlock()
+			scan.id, scan.err = id, err
+			close(scan.done)
+		}()
+	}
+	scan.waiters++
+	c.scanMu.Unlock()
+
+	select {
+	case <-scan.done:
+		return scan.id, scan.err
+	case <-ctx.Done():
+		c.scanMu.Lock()
+		scan.waiters--
+		if scan.waiters == 0 {
+			scan.cancel()
+			if c.scans[key] == scan {
+				delete(c.scans, key)
+			}
+		}
+		c.scanMu.Unlock()
+		return "", ctx.Err()
+	}
+}
+
+// scanChannelDirectory pages through conversations.list, feeding the index,
+// until name is found or maxChannelPages pages have been read.
+func (c *Service) scanChannelDirectory(ctx context.Context, name string, types []string) (string, error) {
+	cursor := ""
+	for page := 0; page < c.maxChannelPages; page++ {
+		_, next, err := c.listConversations(ctx, &slack.GetConversationsParameters{
+			Cursor: cursor,
+			Types:  types,
+			Limit:  1000,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to list channels: %w", err)
+		}
+		cursor = next
+
+		if ch, ok := c.index.GetByName(name); ok {
+			c.logger.Debug("Channel found in directory scan",
+				zap.String("channel_name", ch.NameNormalized),
+				zap.String("channel_id", ch.ID),
+				zap.Int("pages", page+1))
+			return ch.ID, nil
+		}
+		if cursor == "" {
+			return "", fmt.Errorf("channel %q not found", name)
+		}
+	}
+	return "", fmt.Errorf("channel %q not found within %d pages; try resolving by ID", name, c.maxChannelPages)
+}
+
+// channelName resolves a channel ID to its name, consulting the index before the API.
+// Returns an empty string if the channel cannot be resolved.
+func (c *Service) channelName(ctx context.Context, channelID string) string {
+	if ch, ok := c.index.GetByID(channelID); ok {
+		return ch.Name
+	}
+	ch, err := c.getConversationInfo(ctx, channelID)
+	if err != nil {
+		c.logger.Debug("Failed to resolve channel name",
+			zap.String("channel_id", channelID),
+			zap.Error(err))
+		return ""
+	}
+	return ch.Name
+}
+
+// resolveChannelNames resolves each distinct channel ID once, consulting the index
+// before the API. Channels that cannot be resolved map to an empty string.
+func (c *Service) resolveChannelNames(ctx context.Context, channelIDs []string) map[string]string {
+	names := make(map[string]string)
+	for _, id := range channelIDs {
+		if id == "" {
+			continue
+		}
+		if _, done := names[id]; done {
+			continue
+		}
+		names[id] = c.channelName(ctx, id)
+	}
+	return names
+}
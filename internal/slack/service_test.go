@@ -1,10 +1,19 @@
 package slack
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/slack-go/slack"
 	"go.uber.org/zap/zaptest"
@@ -22,7 +31,7 @@ func TestGetChannelID_ConcurrentIDPassthrough(t *testing.T) {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			id, err := client.GetChannelID("CTEST12345")
+			id, err := client.GetChannelID(context.Background(), "CTEST12345")
 			if err != nil {
 				errs <- err
 				return
@@ -59,7 +68,7 @@ func TestGetChannelID_ConcurrentNameLookup(t *testing.T) {
 			defer wg.Done()
 			name := fmt.Sprintf("channel-%d", idx)
 			wantID := fmt.Sprintf("C%09d", idx)
-			id, err := client.GetChannelID(name)
+			id, err := client.GetChannelID(context.Background(), name)
 			if err != nil {
 				errs <- fmt.Errorf("channel %q: %w", name, err)
 				return
@@ -96,7 +105,7 @@ func TestGetChannelID_CacheMiss(t *testing.T) {
 
 	client := newServiceWithIndex(nil, ix, zaptest.NewLogger(t), nil)
 
-	_, err := client.GetChannelID("nonexistent")
+	_, err := client.GetChannelID(context.Background(), "nonexistent")
 	if err == nil {
 		t.Fatal("got nil error, want error for missing channel")
 	}
@@ -113,7 +122,7 @@ func TestFindChannelID_NotInIndex(t *testing.T) {
 	ix := newIndex()
 	client := newServiceWithIndex(nil, ix, zaptest.NewLogger(t), nil)
 
-	_, err := client.GetChannelID("does-not-exist")
+	_, err := client.GetChannelID(context.Background(), "does-not-exist")
 	if err == nil {
 		t.Fatal("got nil error, want error for missing channel")
 	}
@@ -124,6 +133,201 @@ func TestFindChannelID_NotInIndex(t *testing.T) {
 	}
 }
 
+func TestFindChannelID_DirectoryScan(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	pages := 0
+	mock.addHandler("/conversations.list", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		pages++
+		page, _ := strconv.Atoi(strings.TrimPrefix(r.FormValue("cursor"), "page"))
+		next := ""
+		if page < 2 {
+			next = fmt.Sprintf("page%d", page+1)
+		}
+		response := map[string]interface{}{
+			"ok": true,
+			"channels": []map[string]interface{}{
+				{"id": fmt.Sprintf("C00000000%d", page), "name": fmt.Sprintf("page-%d", page), "name_normalized": fmt.Sprintf("page-%d", page)},
+			},
+			"response_metadata": map[string]string{"next_cursor": next},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	tests := []struct {
+		name      string
+		maxPages  int
+		channel   string
+		wantID    string
+		wantErr   string
+		wantPages int
+	}{
+		{"found on last page", 3, "page-2", "C000000002", "", 3},
+		{"cap reached", 2, "page-2", "", `channel "page-2" not found within 2 pages; try resolving by ID`, 2},
+		{"directory exhausted", 5, "missing", "", `channel "missing" not found`, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pages = 0
+			client, _, dir := newTestClient(t, mock)
+			defer os.RemoveAll(dir)
+			client.maxChannelPages = tt.maxPages
+
+			id, err := client.GetChannelID(context.Background(), tt.channel)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("error: got %v, want %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("GetChannelID failed: %v", err)
+			}
+			if id != tt.wantID {
+				t.Errorf("id: got %q, want %q", id, tt.wantID)
+			}
+			if pages != tt.wantPages {
+				t.Errorf("pages fetched: got %d, want %d", pages, tt.wantPages)
+			}
+		})
+	}
+}
+
+func TestFindChannelID_ConcurrentLookupsShareScan(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	mock.addHandler("/conversations.list", func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		response := map[string]interface{}{
+			"ok": true,
+			"channels": []map[string]interface{}{
+				{"id": "C000000001", "name": "general", "name_normalized": "general"},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, dir := newTestClient(t, mock)
+	defer os.RemoveAll(dir)
+	client.maxChannelPages = 1
+
+	const lookups = 10
+	var wg sync.WaitGroup
+	ids := make([]string, lookups)
+	errs := make([]error, lookups)
+	for i := range lookups {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			name := "general"
+			if i%2 == 1 {
+				name = "#General"
+			}
+			ids[i], errs[i] = client.GetChannelID(context.Background(), name)
+		}()
+	}
+
+	// Give every lookup time to join the scan before it completes
+	time.Sleep(100 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for i := range lookups {
+		if errs[i] != nil || ids[i] != "C000000001" {
+			t.Errorf("lookup %d: got %q, %v; want C000000001", i, ids[i], errs[i])
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("conversations.list calls: got %d, want 1", got)
+	}
+}
+
+func TestFindChannelID_ScanCancelledWhenAllCallersLeave(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	started := make(chan struct{}, 1)
+	aborted := make(chan struct{})
+	mock.addHandler("/conversations.list", func(w http.ResponseWriter, r *http.Request) {
+		// Drain the body so the server notices when the client hangs up
+		r.ParseForm()
+		started <- struct{}{}
+		<-r.Context().Done()
+		close(aborted)
+	})
+
+	client, _, dir := newTestClient(t, mock)
+	defer os.RemoveAll(dir)
+	client.maxChannelPages = 1
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errc := make(chan error, 1)
+	go func() {
+		_, err := client.GetChannelID(ctx, "general")
+		errc <- err
+	}()
+
+	<-started
+	cancel()
+	if err := <-errc; !errors.Is(err, context.Canceled) {
+		t.Errorf("GetChannelID error: got %v, want context.Canceled", err)
+	}
+
+	select {
+	case <-aborted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("scan kept running after its only caller was cancelled")
+	}
+}
+
+func TestFindChannelID_GroupDM(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	var gotTypes []string
+	mock.addHandler("/conversations.list", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		gotTypes = strings.Split(r.FormValue("types"), ",")
+		channels := []map[string]interface{}{
+			{"id": "C000000001", "name": "general", "name_normalized": "general"},
+		}
+		if slices.Contains(gotTypes, "mpim") {
+			channels = append(channels, map[string]interface{}{
+				"id": "G000000002", "name": "mpdm-alice--bob--carol-1", "name_normalized": "mpdm-alice--bob--carol-1", "is_mpim": true,
+			})
+		}
+		response := map[string]interface{}{
+			"ok":       true,
+			"channels": channels,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, dir := newTestClient(t, mock)
+	defer os.RemoveAll(dir)
+	client.maxChannelPages = 1
+
+	id, err := client.GetChannelID(context.Background(), "mpdm-alice--bob--carol-1")
+	if err != nil {
+		t.Fatalf("GetChannelID failed: %v", err)
+	}
+	if id != "G000000002" {
+		t.Errorf("id: got %q, want G000000002", id)
+	}
+	want := []string{"public_channel", "private_channel", "mpim"}
+	if !slices.Equal(gotTypes, want) {
+		t.Errorf("types: got %v, want %v", gotTypes, want)
+	}
+}
+
 func TestIsChannelID(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -134,7 +338,15 @@ func TestIsChannelID(t *testing.T) {
 		{"valid D channel (DM)", "D123456789", true},
 		{"valid G channel (group)", "G123456789", true},
 		{"longer valid ID", "C12345678901", true},
+		{"current-length channel", "C05K2PQ7R9A", true},
+		{"current-length DM", "D07ABCD1234", true},
+		{"legacy private channel", "G01ABCDEF", true},
+		{"enterprise shared channel", "C024BE91L", true},
 		{"too short", "C12345", false},
+		{"too long", "C1234567890123456", false},
+		{"enterprise user ID", "W012A3CDE", false},
+		{"user ID", "U05K2PQ7R9A", false},
+		{"team ID", "T0123456789", false},
 		{"starts with lowercase", "c123456789", false},
 		{"starts with invalid letter", "X123456789", false},
 		{"contains lowercase", "C12345678a", false},
@@ -0,0 +1,60 @@
+package slack
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// reSlackTimestamp matches a raw Unix or Slack sec.micro timestamp
+var reSlackTimestamp = regexp.MustCompile(`^\d+(\.\d+)?$`)
+
+// reRelativeDays matches day and week offsets, which time.ParseDuration lacks
+var reRelativeDays = regexp.MustCompile(`^(\d+)([dw])$`)
+
+// parseTimeRange converts the oldest and latest bounds of a time range to the
+// sec.micro timestamps Slack expects. See parseTimeBound for accepted forms.
+func parseTimeRange(oldest, latest string, now time.Time) (string, string, error) {
+	o, err := parseTimeBound(oldest, now)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid oldest: %w", err)
+	}
+	l, err := parseTimeBound(latest, now)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid latest: %w", err)
+	}
+	return o, l, nil
+}
+
+// parseTimeBound accepts a raw Unix or Slack timestamp (returned unchanged),
+// an RFC3339 time, a YYYY-MM-DD date (midnight in now's time zone, which
+// callers set to the display zone), or a duration before now such as 7d, 2w,
+// 24h or 90m. Empty input stays empty.
+func parseTimeBound(v string, now time.Time) (string, error) {
+	if v == "" || reSlackTimestamp.MatchString(v) {
+		return v, nil
+	}
+	if t, err := time.Parse(time.RFC3339, v); err == nil {
+		return slackTimestamp(t), nil
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, v, now.Location()); err == nil {
+		return slackTimestamp(t), nil
+	}
+	if m := reRelativeDays.FindStringSubmatch(v); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		if m[2] == "w" {
+			n *= 7
+		}
+		return slackTimestamp(now.AddDate(0, 0, -n)), nil
+	}
+	if d, err := time.ParseDuration(v); err == nil && d > 0 {
+		return slackTimestamp(now.Add(-d)), nil
+	}
+	return "", fmt.Errorf("%q is not a Unix timestamp, RFC3339 time, YYYY-MM-DD date, or duration such as 7d or 24h", v)
+}
+
+// slackTimestamp formats t as a Slack sec.micro timestamp
+func slackTimestamp(t time.Time) string {
+	return fmt.Sprintf("%d.%06d", t.Unix(), t.Nanosecond()/1000)
+}
@@ -0,0 +1,75 @@
+package slack
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimeBound(t *testing.T) {
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	midnight := time.Date(2024, 1, 1, 0, 0, 0, 0, now.Location())
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"empty", "", ""},
+		{"unix seconds", "1704067200", "1704067200"},
+		{"slack timestamp", "1704067200.123456", "1704067200.123456"},
+		{"rfc3339", "2024-01-01T00:00:00Z", "1704067200.000000"},
+		{"rfc3339 with offset", "2024-01-01T01:00:00+01:00", "1704067200.000000"},
+		{"date", "2024-01-01", slackTimestamp(midnight)},
+		{"days", "7d", slackTimestamp(now.AddDate(0, 0, -7))},
+		{"weeks", "2w", slackTimestamp(now.AddDate(0, 0, -14))},
+		{"hours", "24h", slackTimestamp(now.Add(-24 * time.Hour))},
+		{"minutes", "90m", slackTimestamp(now.Add(-90 * time.Minute))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTimeBound(tt.input, now)
+			if err != nil {
+				t.Fatalf("parseTimeBound(%q) failed: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("parseTimeBound(%q): got %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTimeBound_DateInNowZone(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("LoadLocation failed: %v", err)
+	}
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, tokyo)
+
+	got, err := parseTimeBound("2024-01-01", now)
+	if err != nil {
+		t.Fatalf("parseTimeBound failed: %v", err)
+	}
+	// Midnight in Tokyo is 15:00 UTC the previous day
+	if want := "1704034800.000000"; got != want {
+		t.Errorf("parseTimeBound: got %q, want %q", got, want)
+	}
+}
+
+func TestParseTimeBound_Invalid(t *testing.T) {
+	now := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	for _, input := range []string{"yesterday", "2024-13-01", "7x", "-24h", "0h", "1704067200.abc"} {
+		t.Run(input, func(t *testing.T) {
+			if got, err := parseTimeBound(input, now); err == nil {
+				t.Errorf("parseTimeBound(%q): got %q, want error", input, got)
+			}
+		})
+	}
+}
+
+func TestParseTimeRange_NamesInvalidBound(t *testing.T) {
+	_, _, err := parseTimeRange("7d", "soon", time.Now())
+	if err == nil || err.Error() != `invalid latest: "soon" is not a Unix timestamp, RFC3339 time, YYYY-MM-DD date, or duration such as 7d or 24h` {
+		t.Errorf("error: got %v", err)
+	}
+}
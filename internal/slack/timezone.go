@@ -0,0 +1,67 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// displayLocation returns the time zone timestamps are formatted in,
+// defaulting to UTC until ResolveTimezone succeeds
+func (c *Service) displayLocation() *time.Location {
+	if loc := c.location.Load(); loc != nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// ResolveTimezone sets the time zone this service formats timestamps in. An
+// explicit Config.Timezone takes precedence; otherwise the authenticated
+// user's profile time zone is looked up via auth.test and users.info. The
+// result is cached, so only the first call hits the API.
+func (c *Service) ResolveTimezone(ctx context.Context) (*time.Location, error) {
+	c.tzMu.Lock()
+	defer c.tzMu.Unlock()
+
+	if loc := c.location.Load(); loc != nil {
+		return loc, nil
+	}
+
+	name := c.timezone
+	if name == "" {
+		var err error
+		name, err = c.authenticatedUserTimezone(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone %q: %w", name, err)
+	}
+
+	c.location.Store(loc)
+	c.logger.Info("Resolved display timezone", zap.String("timezone", loc.String()))
+	return loc, nil
+}
+
+// authenticatedUserTimezone returns the IANA time zone from the authenticated user's profile
+func (c *Service) authenticatedUserTimezone(ctx context.Context) (string, error) {
+	auth, err := c.api.AuthTestContext(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to identify authenticated user: %w", err)
+	}
+
+	user, err := c.api.GetUserInfoContext(ctx, auth.UserID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get authenticated user: %w", err)
+	}
+
+	if user.TZ == "" {
+		return "UTC", nil
+	}
+	return user.TZ, nil
+}
@@ -0,0 +1,112 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestResolveTimezone_FromAuthenticatedUser(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	authCalls := 0
+	mock.addHandler("/auth.test", func(w http.ResponseWriter, r *http.Request) {
+		authCalls++
+		response := map[string]interface{}{
+			"ok":      true,
+			"user_id": "U123456789",
+			"user":    "alice",
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/users.info", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"user": map[string]interface{}{
+				"id":   "U123456789",
+				"name": "alice",
+				"tz":   "America/Chicago",
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	ctx := context.Background()
+	loc, err := client.ResolveTimezone(ctx)
+	if err != nil {
+		t.Fatalf("ResolveTimezone failed: %v", err)
+	}
+	if loc.String() != "America/Chicago" {
+		t.Errorf("location: got %q, want %q", loc.String(), "America/Chicago")
+	}
+
+	if _, err := client.ResolveTimezone(ctx); err != nil {
+		t.Fatalf("second ResolveTimezone failed: %v", err)
+	}
+	if authCalls != 1 {
+		t.Errorf("auth.test calls: got %d, want 1 (cached)", authCalls)
+	}
+
+	if got, want := newTimestamp("1234567890.123456", client.displayLocation()), Timestamp("2009-02-13T17:31:30.123456-06:00"); got != want {
+		t.Errorf("timestamp: got %q, want %q", got, want)
+	}
+}
+
+func TestResolveTimezone_ConfigOverride(t *testing.T) {
+	client := newServiceWithIndex(nil, nil, nil, nil)
+	client.timezone = "Asia/Tokyo"
+
+	loc, err := client.ResolveTimezone(context.Background())
+	if err != nil {
+		t.Fatalf("ResolveTimezone failed: %v", err)
+	}
+	if loc.String() != "Asia/Tokyo" {
+		t.Errorf("location: got %q, want %q", loc.String(), "Asia/Tokyo")
+	}
+
+	if got, want := formatSlackTimestamp("1234567890.123456", client.displayLocation()), "2009-02-14T08:31:30+09:00"; got != want {
+		t.Errorf("formatSlackTimestamp: got %q, want %q", got, want)
+	}
+}
+
+func TestResolveTimezone_InvalidZone(t *testing.T) {
+	client := newServiceWithIndex(nil, nil, nil, nil)
+	client.timezone = "Not/AZone"
+
+	if _, err := client.ResolveTimezone(context.Background()); err == nil {
+		t.Error("expected error for invalid timezone")
+	}
+}
+
+func TestResolveTimezone_PerService(t *testing.T) {
+	tokyo := newServiceWithIndex(nil, nil, nil, nil)
+	tokyo.timezone = "Asia/Tokyo"
+	chicago := newServiceWithIndex(nil, nil, nil, nil)
+	chicago.timezone = "America/Chicago"
+
+	for _, svc := range []*Service{tokyo, chicago} {
+		if _, err := svc.ResolveTimezone(context.Background()); err != nil {
+			t.Fatalf("ResolveTimezone failed: %v", err)
+		}
+	}
+
+	if got := tokyo.displayLocation().String(); got != "Asia/Tokyo" {
+		t.Errorf("first service location: got %q, want Asia/Tokyo", got)
+	}
+	if got := chicago.displayLocation().String(); got != "America/Chicago" {
+		t.Errorf("second service location: got %q, want America/Chicago", got)
+	}
+	if got := newServiceWithIndex(nil, nil, nil, nil).displayLocation(); got != time.UTC {
+		t.Errorf("unresolved service location: got %v, want UTC", got)
+	}
+}
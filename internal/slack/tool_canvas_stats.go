@@ -0,0 +1,75 @@
+package slack
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+)
+
+// charsPerToken is a rough estimate of characters per LLM token for English text
+const charsPerToken = 4
+
+// CanvasStatsInput defines input for computing canvas statistics
+type CanvasStatsInput struct {
+	Channel string `json:"channel,omitempty" jsonschema:"Channel ID or name (for channel canvases)"`
+	FileID  string `json:"file_id,omitempty" jsonschema:"Canvas file ID (for standalone canvases)"`
+
+	WorkspaceSelector
+}
+
+// CanvasStatsOutput contains size statistics for a canvas
+type CanvasStatsOutput struct {
+	FileID          string `json:"file_id"`
+	Title           string `json:"title"`
+	WordCount       int    `json:"word_count"`
+	CharCount       int    `json:"char_count"`
+	HeadingCount    int    `json:"heading_count"`
+	SectionCount    int    `json:"section_count"`
+	EstimatedTokens int    `json:"estimated_tokens"`
+}
+
+// CanvasStats downloads a canvas and reports its size without returning the content
+func (c *Service) CanvasStats(ctx context.Context, input CanvasStatsInput) (CanvasStatsOutput, error) {
+	canvas, err := c.fetchCanvas(ctx, input.Channel, input.FileID)
+	if err != nil {
+		return CanvasStatsOutput{}, err
+	}
+
+	stats := computeTextStats(canvas.Text)
+	stats.FileID = canvas.FileID
+	stats.Title = canvas.Title
+	return stats, nil
+}
+
+// computeTextStats counts words, characters, and headings in text produced by stripHTML.
+// Heading and list markers are not counted as words.
+// Sections are delimited by headings; any content before the first heading is its own section.
+func computeTextStats(text string) CanvasStatsOutput {
+	chars := utf8.RuneCountInString(text)
+	stats := CanvasStatsOutput{
+		CharCount:       chars,
+		EstimatedTokens: (chars + charsPerToken - 1) / charsPerToken,
+	}
+
+	for _, word := range strings.Fields(text) {
+		if strings.Trim(word, "#-") != "" {
+			stats.WordCount++
+		}
+	}
+
+	hasContent := false
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(line, "#") {
+			stats.HeadingCount++
+			stats.SectionCount++
+			hasContent = true
+			continue
+		}
+		if line != "" && !hasContent {
+			stats.SectionCount++
+			hasContent = true
+		}
+	}
+
+	return stats
+}
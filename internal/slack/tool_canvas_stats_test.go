@@ -0,0 +1,86 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+)
+
+func TestCanvasStats(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	canvasHTML := "<h1>Roadmap</h1><p>Intro words here</p>" +
+		"<h2>Part A</h2><p>one two three</p>" +
+		"<h2>Part B</h2><ul><li>x</li><li>y</li></ul>"
+
+	mock.addHandler("/files.info", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"file": map[string]interface{}{
+				"id":                   "F123CANVAS",
+				"title":                "Roadmap",
+				"filetype":             "quip",
+				"url_private_download": mock.server.URL + "/files/F123CANVAS/download",
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/files/F123CANVAS/download", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(canvasHTML))
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	output, err := client.CanvasStats(context.Background(), CanvasStatsInput{FileID: "F123CANVAS"})
+	if err != nil {
+		t.Fatalf("CanvasStats failed: %v", err)
+	}
+
+	if output.Title != "Roadmap" {
+		t.Errorf("Title: got %q, want %q", output.Title, "Roadmap")
+	}
+	if output.WordCount != 13 {
+		t.Errorf("WordCount: got %d, want 13", output.WordCount)
+	}
+	if output.HeadingCount != 3 {
+		t.Errorf("HeadingCount: got %d, want 3", output.HeadingCount)
+	}
+	if output.SectionCount != 3 {
+		t.Errorf("SectionCount: got %d, want 3", output.SectionCount)
+	}
+	if output.CharCount == 0 {
+		t.Error("CharCount: got 0, want non-zero")
+	}
+	if want := (output.CharCount + 3) / 4; output.EstimatedTokens != want {
+		t.Errorf("EstimatedTokens: got %d, want %d", output.EstimatedTokens, want)
+	}
+
+	entries, err := os.ReadDir(responsesDir)
+	if err != nil {
+		t.Fatalf("Failed to read responses dir: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("response files: got %d, want 0", len(entries))
+	}
+}
+
+func TestComputeTextStats_LeadingContentIsSection(t *testing.T) {
+	got := computeTextStats("Preamble text\n\n# Heading\n\nBody")
+
+	if got.SectionCount != 2 {
+		t.Errorf("SectionCount: got %d, want 2", got.SectionCount)
+	}
+	if got.HeadingCount != 1 {
+		t.Errorf("HeadingCount: got %d, want 1", got.HeadingCount)
+	}
+	if got.WordCount != 4 {
+		t.Errorf("WordCount: got %d, want 4", got.WordCount)
+	}
+}
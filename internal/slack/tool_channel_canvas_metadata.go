@@ -0,0 +1,47 @@
+package slack
+
+import (
+	"context"
+)
+
+// ChannelCanvasMetadataInput defines input for looking up a channel's canvas
+type ChannelCanvasMetadataInput struct {
+	Channel string `json:"channel" jsonschema:"Channel ID or name"`
+
+	WorkspaceSelector
+}
+
+// ChannelCanvasMetadataOutput describes a channel's canvas without its content
+type ChannelCanvasMetadataOutput struct {
+	ChannelID string `json:"channel_id"`
+	HasCanvas bool   `json:"has_canvas"`
+	FileID    string `json:"file_id,omitempty"`
+	Title     string `json:"title,omitempty"`
+	IsEmpty   bool   `json:"is_empty,omitempty"`
+	Bytes     int    `json:"bytes,omitempty"`
+}
+
+// GetChannelCanvasMetadata reports whether a channel has a canvas, and its
+// title and size if so, without downloading the canvas
+func (c *Service) GetChannelCanvasMetadata(ctx context.Context, input ChannelCanvasMetadataInput) (ChannelCanvasMetadataOutput, error) {
+	channelID, canvas, err := c.channelCanvas(ctx, input.Channel)
+	if err != nil {
+		return ChannelCanvasMetadataOutput{}, err
+	}
+
+	output := ChannelCanvasMetadataOutput{ChannelID: channelID}
+	if canvas == nil {
+		return output, nil
+	}
+	output.HasCanvas = true
+	output.FileID = canvas.FileId
+	output.IsEmpty = canvas.IsEmpty
+
+	file, err := c.getFileInfo(ctx, canvas.FileId)
+	if err != nil {
+		return ChannelCanvasMetadataOutput{}, err
+	}
+	output.Title = file.Title
+	output.Bytes = file.Size
+	return output, nil
+}
@@ -0,0 +1,80 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+)
+
+func TestGetChannelCanvasMetadata(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	mock.addHandler("/conversations.info", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		channel := map[string]interface{}{"id": "C000000001", "name": "general"}
+		if r.FormValue("channel") == "C123456789" {
+			channel = map[string]interface{}{
+				"id":   "C123456789",
+				"name": "design-docs",
+				"properties": map[string]interface{}{
+					"canvas": map[string]interface{}{"file_id": "F456CANVAS", "is_empty": false},
+				},
+			}
+		}
+		response := map[string]interface{}{"ok": true, "channel": channel}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/files.info", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"file": map[string]interface{}{
+				"id":       "F456CANVAS",
+				"title":    "Channel Canvas",
+				"filetype": "quip",
+				"size":     2048,
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	downloaded := false
+	mock.addHandler("/files/F456CANVAS/download", func(w http.ResponseWriter, r *http.Request) {
+		downloaded = true
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	tests := []struct {
+		name    string
+		channel string
+		want    ChannelCanvasMetadataOutput
+	}{
+		{"with canvas", "C123456789", ChannelCanvasMetadataOutput{
+			ChannelID: "C123456789", HasCanvas: true, FileID: "F456CANVAS", Title: "Channel Canvas", Bytes: 2048,
+		}},
+		{"without canvas", "C000000001", ChannelCanvasMetadataOutput{ChannelID: "C000000001"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.GetChannelCanvasMetadata(context.Background(), ChannelCanvasMetadataInput{Channel: tt.channel})
+			if err != nil {
+				t.Fatalf("GetChannelCanvasMetadata failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if downloaded {
+		t.Error("canvas content was downloaded")
+	}
+}
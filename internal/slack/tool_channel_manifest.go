@@ -0,0 +1,158 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/slack-go/slack"
+	"go.uber.org/zap"
+)
+
+// ChannelManifestInput defines input for building a channel manifest
+type ChannelManifestInput struct {
+	Channel string `json:"channel" jsonschema:"Channel ID or name (e.g., C1234567890 or #general)"`
+
+	WorkspaceSelector
+}
+
+// ManifestMember is a channel member, with email when it could be resolved
+type ManifestMember struct {
+	ID    string `json:"id"`
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+// ManifestBookmark is a link bookmarked in the channel header
+type ManifestBookmark struct {
+	Title string `json:"title"`
+	Link  string `json:"link"`
+	Emoji string `json:"emoji,omitempty"`
+	Type  string `json:"type"`
+}
+
+// ChannelManifest is everything needed to re-create a channel elsewhere
+type ChannelManifest struct {
+	Channel   ChannelInfo        `json:"channel"`
+	Members   []ManifestMember   `json:"members"`
+	Pins      []MessageInfo      `json:"pins"`
+	Bookmarks []ManifestBookmark `json:"bookmarks"`
+	// Unresolved lists members whose profile couldn't be fetched; they
+	// appear in Members with only an ID
+	Unresolved []string `json:"unresolved,omitempty"`
+}
+
+// ChannelManifestOutput references the manifest file and summarizes its contents
+type ChannelManifestOutput struct {
+	File          FileRef `json:"file"`
+	ChannelID     string  `json:"channel_id"`
+	MemberCount   int     `json:"member_count"`
+	PinCount      int     `json:"pin_count"`
+	BookmarkCount int     `json:"bookmark_count"`
+	// UnresolvedMembers lists members whose name and email are missing from
+	// the manifest because users.info failed for them
+	UnresolvedMembers []string `json:"unresolved_members,omitempty"`
+}
+
+// ChannelManifest bundles a channel's metadata, members, pinned messages and
+// bookmarks into a single JSON file for migrating the channel elsewhere.
+func (c *Service) ChannelManifest(ctx context.Context, input ChannelManifestInput) (ChannelManifestOutput, error) {
+	channelID, err := c.GetChannelID(ctx, input.Channel)
+	if err != nil {
+		return ChannelManifestOutput{}, err
+	}
+
+	ch, err := c.getConversationInfo(ctx, channelID)
+	if err != nil {
+		return ChannelManifestOutput{}, fmt.Errorf("failed to get channel info: %w", err)
+	}
+
+	memberIDs, err := c.conversationMembers(ctx, channelID)
+	if err != nil {
+		return ChannelManifestOutput{}, err
+	}
+
+	pins, err := c.pinnedMessages(ctx, channelID)
+	if err != nil {
+		return ChannelManifestOutput{}, err
+	}
+
+	bookmarks, err := c.api.ListBookmarksContext(ctx, channelID)
+	if err != nil {
+		return ChannelManifestOutput{}, fmt.Errorf("failed to list bookmarks: %w", err)
+	}
+
+	manifest := ChannelManifest{
+		Channel:   newChannelInfo(*ch, c.newUserNameCache(ctx), c.displayLocation()),
+		Members:   make([]ManifestMember, 0, len(memberIDs)),
+		Pins:      pins,
+		Bookmarks: make([]ManifestBookmark, 0, len(bookmarks)),
+	}
+	users := c.lookupUsers(ctx, memberIDs)
+	for _, id := range memberIDs {
+		member := ManifestMember{ID: id}
+		if user, ok := users[id]; ok {
+			member.Name = user.Name
+			member.Email = user.Profile.Email
+		} else {
+			manifest.Unresolved = append(manifest.Unresolved, id)
+		}
+		manifest.Members = append(manifest.Members, member)
+	}
+	for _, b := range bookmarks {
+		manifest.Bookmarks = append(manifest.Bookmarks, ManifestBookmark{
+			Title: b.Title,
+			Link:  b.Link,
+			Emoji: b.Emoji,
+			Type:  b.Type,
+		})
+	}
+
+	ref, err := c.responses.WriteJSON("manifest", manifest)
+	if err != nil {
+		return ChannelManifestOutput{}, fmt.Errorf("failed to write response: %w", err)
+	}
+
+	return ChannelManifestOutput{
+		File:          ref,
+		ChannelID:     channelID,
+		MemberCount:   len(manifest.Members),
+		PinCount:      len(manifest.Pins),
+		BookmarkCount: len(manifest.Bookmarks),
+
+		UnresolvedMembers: manifest.Unresolved,
+	}, nil
+}
+
+// lookupUsers fetches full profiles for userIDs, with at most
+// userLookupConcurrency requests in flight. Users that can't be fetched are
+// left out of the result.
+func (c *Service) lookupUsers(ctx context.Context, userIDs []string) map[string]*slack.User {
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		users = make(map[string]*slack.User, len(userIDs))
+	)
+	sem := make(chan struct{}, userLookupConcurrency)
+	for _, id := range userIDs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(id string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			user, err := c.api.GetUserInfoContext(ctx, id)
+			if err != nil {
+				c.logger.Warn("Failed to look up channel member",
+					zap.String("user", id),
+					zap.Error(err))
+				return
+			}
+			mu.Lock()
+			users[id] = user
+			mu.Unlock()
+		}(id)
+	}
+	wg.Wait()
+	return users
+}
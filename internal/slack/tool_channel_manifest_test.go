@@ -0,0 +1,140 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestChannelManifest(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	mock.addHandler("/conversations.info", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"channel": map[string]interface{}{
+				"id":         "C123456789",
+				"name":       "ops",
+				"is_private": true,
+				"topic":      map[string]string{"value": "Incidents"},
+				"purpose":    map[string]string{"value": "On-call coordination"},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/conversations.members", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok":                true,
+			"members":           []string{"U111", "U222"},
+			"response_metadata": map[string]string{"next_cursor": ""},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/users.info", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		var response map[string]interface{}
+		if r.FormValue("user") == "U111" {
+			response = map[string]interface{}{
+				"ok": true,
+				"user": map[string]interface{}{
+					"id":      "U111",
+					"name":    "alice",
+					"profile": map[string]interface{}{"email": "alice@example.com"},
+				},
+			}
+		} else {
+			response = map[string]interface{}{"ok": false, "error": "user_not_found"}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/pins.list", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"items": []map[string]interface{}{
+				{
+					"type":    "message",
+					"channel": "C123456789",
+					"message": map[string]interface{}{"type": "message", "user": "U111", "text": "Runbook", "ts": "1700000000.000100"},
+				},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/bookmarks.list", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"bookmarks": []map[string]interface{}{
+				{"id": "Bk1", "title": "Dashboard", "link": "https://example.com/dash", "type": "link"},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	output, err := client.ChannelManifest(context.Background(), ChannelManifestInput{Channel: "C123456789"})
+	if err != nil {
+		t.Fatalf("ChannelManifest failed: %v", err)
+	}
+
+	if output.MemberCount != 2 || output.PinCount != 1 || output.BookmarkCount != 1 {
+		t.Errorf("counts: got members=%d pins=%d bookmarks=%d, want 2, 1, 1",
+			output.MemberCount, output.PinCount, output.BookmarkCount)
+	}
+
+	if !slices.Equal(output.UnresolvedMembers, []string{"U222"}) {
+		t.Errorf("UnresolvedMembers: got %v, want [U222]", output.UnresolvedMembers)
+	}
+
+	content, err := os.ReadFile(output.File.Path)
+	if err != nil {
+		t.Fatalf("failed to read manifest: %v", err)
+	}
+	var manifest ChannelManifest
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		t.Fatalf("failed to parse manifest: %v", err)
+	}
+
+	if manifest.Channel.Name != "ops" || !manifest.Channel.IsPrivate || manifest.Channel.Topic != "Incidents" {
+		t.Errorf("Channel: got %+v, want private #ops with topic Incidents", manifest.Channel)
+	}
+
+	wantMembers := []ManifestMember{
+		{ID: "U111", Name: "alice", Email: "alice@example.com"},
+		{ID: "U222"},
+	}
+	if len(manifest.Members) != len(wantMembers) {
+		t.Fatalf("Members: got %+v, want %+v", manifest.Members, wantMembers)
+	}
+	for i, want := range wantMembers {
+		if manifest.Members[i] != want {
+			t.Errorf("Members[%d]: got %+v, want %+v", i, manifest.Members[i], want)
+		}
+	}
+
+	if !slices.Equal(manifest.Unresolved, []string{"U222"}) {
+		t.Errorf("Unresolved: got %v, want [U222]", manifest.Unresolved)
+	}
+
+	if len(manifest.Pins) != 1 || manifest.Pins[0].Text != "Runbook" {
+		t.Errorf("Pins: got %+v, want one pin with text Runbook", manifest.Pins)
+	}
+
+	if len(manifest.Bookmarks) != 1 || manifest.Bookmarks[0].Link != "https://example.com/dash" {
+		t.Errorf("Bookmarks: got %+v, want the dashboard link", manifest.Bookmarks)
+	}
+}
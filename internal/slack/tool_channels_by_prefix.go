@@ -0,0 +1,59 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// ChannelsByPrefixInput defines input for finding channels by name prefix
+type ChannelsByPrefixInput struct {
+	Prefix          string `json:"prefix" jsonschema:"Channel name prefix (e.g., team- or proj-)"`
+	IncludeArchived bool   `json:"include_archived,omitempty" jsonschema:"Include archived channels"`
+
+	WorkspaceSelector
+}
+
+// ChannelsByPrefixOutput contains the channels whose names start with the prefix
+type ChannelsByPrefixOutput struct {
+	Channels   []ChannelInfo `json:"channels"`
+	TotalCount int           `json:"total_count"`
+}
+
+// ChannelsByPrefix lists every public and private channel whose normalized name starts with a prefix
+func (c *Service) ChannelsByPrefix(ctx context.Context, input ChannelsByPrefixInput) (ChannelsByPrefixOutput, error) {
+	prefix := strings.ToLower(strings.TrimPrefix(input.Prefix, "#"))
+	if prefix == "" {
+		return ChannelsByPrefixOutput{}, fmt.Errorf("prefix is required")
+	}
+
+	output := ChannelsByPrefixOutput{Channels: []ChannelInfo{}}
+	cursor := ""
+	for {
+		channels, next, err := c.listConversations(ctx, &slack.GetConversationsParameters{
+			Cursor:          cursor,
+			ExcludeArchived: !input.IncludeArchived,
+			Types:           []string{"public_channel", "private_channel"},
+			Limit:           1000,
+		})
+		if err != nil {
+			return ChannelsByPrefixOutput{}, fmt.Errorf("failed to list channels: %w", err)
+		}
+
+		for _, ch := range channels {
+			if strings.HasPrefix(strings.ToLower(ch.NameNormalized), prefix) {
+				output.Channels = append(output.Channels, newChannelInfo(ch, nil, c.displayLocation()))
+			}
+		}
+
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	output.TotalCount = len(output.Channels)
+	return output, nil
+}
@@ -0,0 +1,74 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+)
+
+func TestChannelsByPrefix(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	var gotExcludeArchived string
+	mock.addHandler("/conversations.list", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		gotExcludeArchived = r.FormValue("exclude_archived")
+
+		var response map[string]interface{}
+		if r.FormValue("cursor") == "" {
+			response = map[string]interface{}{
+				"ok": true,
+				"channels": []map[string]interface{}{
+					{"id": "C000000001", "name": "team-alpha", "name_normalized": "team-alpha"},
+					{"id": "C000000002", "name": "general", "name_normalized": "general"},
+				},
+				"response_metadata": map[string]string{"next_cursor": "page2"},
+			}
+		} else {
+			response = map[string]interface{}{
+				"ok": true,
+				"channels": []map[string]interface{}{
+					{"id": "C000000003", "name": "Team-Beta", "name_normalized": "team-beta"},
+					{"id": "C000000004", "name": "steam-room", "name_normalized": "steam-room"},
+				},
+				"response_metadata": map[string]string{"next_cursor": ""},
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	output, err := client.ChannelsByPrefix(context.Background(), ChannelsByPrefixInput{Prefix: "#team-"})
+	if err != nil {
+		t.Fatalf("ChannelsByPrefix failed: %v", err)
+	}
+
+	if gotExcludeArchived != "true" {
+		t.Errorf("exclude_archived: got %q, want true", gotExcludeArchived)
+	}
+
+	wantIDs := []string{"C000000001", "C000000003"}
+	if output.TotalCount != len(wantIDs) {
+		t.Fatalf("TotalCount: got %d, want %d", output.TotalCount, len(wantIDs))
+	}
+	for i, want := range wantIDs {
+		if got := output.Channels[i].ID; got != want {
+			t.Errorf("Channels[%d].ID: got %s, want %s", i, got, want)
+		}
+	}
+}
+
+func TestChannelsByPrefix_EmptyPrefix(t *testing.T) {
+	client := newServiceWithIndex(nil, nil, nil, nil)
+
+	_, err := client.ChannelsByPrefix(context.Background(), ChannelsByPrefixInput{Prefix: "#"})
+	if err == nil {
+		t.Error("expected error for empty prefix")
+	}
+}
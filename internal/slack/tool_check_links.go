@@ -0,0 +1,259 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// reLink matches URLs in Slack's <https://...> or <https://...|label> syntax
+var reLink = regexp.MustCompile(`<(https?://[^|>]+)(?:\|[^>]*)?>`)
+
+// defaultLinkCheckTimeout is the per-request timeout used when Config.LinkCheckTimeout is zero
+const defaultLinkCheckTimeout = 10 * time.Second
+
+// linkCheckConcurrency bounds how many links are checked at once
+const linkCheckConcurrency = 5
+
+// maxLinkChecks bounds how many distinct URLs one CheckLinks call requests
+const maxLinkChecks = 200
+
+// maxLinkRedirects bounds how many redirects are followed for one link
+const maxLinkRedirects = 5
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), which
+// netip.Addr.IsPrivate does not cover
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// CheckLinksInput defines input for checking links posted in a channel
+type CheckLinksInput struct {
+	Channel string `json:"channel" jsonschema:"Channel ID or name (e.g., C1234567890 or #general)"`
+	Oldest  string `json:"oldest,omitempty" jsonschema:"Start of time range (Unix timestamp)"`
+	Latest  string `json:"latest,omitempty" jsonschema:"End of time range (Unix timestamp)"`
+
+	WorkspaceSelector
+}
+
+// LinkStatus is the result of checking one posted link
+type LinkStatus struct {
+	URL              string `json:"url"`
+	Status           string `json:"status"`
+	StatusCode       int    `json:"status_code,omitempty"`
+	Error            string `json:"error,omitempty"`
+	MessageTimestamp string `json:"message_ts"`
+}
+
+// Link check outcomes reported in LinkStatus.Status
+const (
+	LinkReachable = "reachable"
+	LinkBroken    = "broken"
+	LinkSkipped   = "skipped"
+)
+
+// CheckLinksOutput contains one entry per link occurrence, newest message first
+type CheckLinksOutput struct {
+	ChannelID   string       `json:"channel_id"`
+	Links       []LinkStatus `json:"links"`
+	TotalCount  int          `json:"total_count"`
+	BrokenCount int          `json:"broken_count"`
+
+	// SkippedCount counts occurrences of URLs beyond the first maxLinkChecks
+	// distinct ones, which are not requested
+	SkippedCount int `json:"skipped_count,omitempty"`
+}
+
+// linkResult is the outcome of a single HTTP check, shared by every occurrence of a URL
+type linkResult struct {
+	statusCode int
+	err        error
+}
+
+// CheckLinks extracts URLs from a channel's messages and checks each with an
+// HTTP HEAD request. Links to Slack itself are skipped. Each distinct URL is
+// requested once, with at most linkCheckConcurrency requests in flight and
+// at most maxLinkChecks URLs per call. Only public addresses are contacted,
+// since anyone in the channel chooses the URLs.
+func (c *Service) CheckLinks(ctx context.Context, input CheckLinksInput) (CheckLinksOutput, error) {
+	channelID, err := c.GetChannelID(ctx, input.Channel)
+	if err != nil {
+		return CheckLinksOutput{}, err
+	}
+
+	var links []LinkStatus
+	err = c.walkHistory(ctx, channelID, input.Oldest, input.Latest, func(messages []slack.Message) {
+		for _, msg := range messages {
+			for _, m := range reLink.FindAllStringSubmatch(msg.Text, -1) {
+				if isSlackURL(m[1]) {
+					continue
+				}
+				links = append(links, LinkStatus{URL: m[1], MessageTimestamp: msg.Timestamp})
+			}
+		}
+	})
+	if err != nil {
+		return CheckLinksOutput{}, err
+	}
+
+	results := c.checkURLs(ctx, links)
+
+	output := CheckLinksOutput{
+		ChannelID:  channelID,
+		Links:      make([]LinkStatus, 0, len(links)),
+		TotalCount: len(links),
+	}
+	for _, link := range links {
+		res, ok := results[link.URL]
+		if !ok {
+			link.Status = LinkSkipped
+			link.Error = fmt.Sprintf("not checked: more than %d distinct links", maxLinkChecks)
+			output.SkippedCount++
+			output.Links = append(output.Links, link)
+			continue
+		}
+		link.StatusCode = res.statusCode
+		link.Status = LinkReachable
+		if res.err != nil {
+			link.Error = res.err.Error()
+		}
+		if res.err != nil || res.statusCode >= 400 {
+			link.Status = LinkBroken
+			output.BrokenCount++
+		}
+		output.Links = append(output.Links, link)
+	}
+
+	return output, nil
+}
+
+// checkURLs sends a HEAD request to each of the first maxLinkChecks
+// distinct URLs in links
+func (c *Service) checkURLs(ctx context.Context, links []LinkStatus) map[string]linkResult {
+	timeout := c.linkCheckTimeout
+	if timeout <= 0 {
+		timeout = defaultLinkCheckTimeout
+	}
+	checkAddr := c.linkAddressCheck
+	if checkAddr == nil {
+		checkAddr = requirePublicAddress
+	}
+	client := newLinkCheckClient(timeout, checkAddr)
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := make(map[string]linkResult)
+	seen := make(map[string]bool)
+	sem := make(chan struct{}, linkCheckConcurrency)
+
+	for _, link := range links {
+		if seen[link.URL] || len(seen) == maxLinkChecks {
+			continue
+		}
+		seen[link.URL] = true
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(u string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			res := headURL(ctx, client, u)
+			mu.Lock()
+			results[u] = res
+			mu.Unlock()
+		}(link.URL)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// newLinkCheckClient returns an HTTP client for checking posted links. Every
+// connection, including those made for redirects, is refused unless
+// checkAddr accepts the resolved IP address. Proxies are not used, since the
+// proxy's address would be checked instead of the link's.
+func newLinkCheckClient(timeout time.Duration, checkAddr func(netip.Addr) error) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			ap, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return err
+			}
+			return checkAddr(ap.Addr().Unmap())
+		},
+	}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &http.Transport{DialContext: dialer.DialContext},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxLinkRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxLinkRedirects)
+			}
+			return nil
+		},
+	}
+}
+
+// errNonPublicAddress is returned for links that resolve to a non-public address
+var errNonPublicAddress = errors.New("refusing to check non-public address")
+
+// requirePublicAddress rejects loopback, private, link-local and other
+// addresses that are not reachable on the public internet
+func requirePublicAddress(addr netip.Addr) error {
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() || addr.IsMulticast() || addr.IsUnspecified() ||
+		sharedAddressSpace.Contains(addr) {
+		return fmt.Errorf("%w: %s", errNonPublicAddress, addr)
+	}
+	return nil
+}
+
+// headURL performs a HEAD request and reports the status code or error. Many
+// servers reject HEAD, so a 405 or 501 response is retried with GET.
+func headURL(ctx context.Context, client *http.Client, u string) linkResult {
+	res := requestURL(ctx, client, http.MethodHead, u)
+	if res.statusCode == http.StatusMethodNotAllowed || res.statusCode == http.StatusNotImplemented {
+		res = requestURL(ctx, client, http.MethodGet, u)
+	}
+	return res
+}
+
+// requestURL sends one request without reading the response body
+func requestURL(ctx context.Context, client *http.Client, method, u string) linkResult {
+	req, err := http.NewRequestWithContext(ctx, method, u, nil)
+	if err != nil {
+		return linkResult{err: err}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return linkResult{err: err}
+	}
+	resp.Body.Close()
+	return linkResult{statusCode: resp.StatusCode}
+}
+
+// isSlackURL reports whether u points at Slack itself (workspaces, files, archives)
+func isSlackURL(u string) bool {
+	parsed, err := url.Parse(u)
+	if err != nil {
+		return false
+	}
+	host := parsed.Hostname()
+	for _, domain := range []string{"slack.com", "slack-edge.com", "slack-files.com"} {
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,283 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// allowAnyAddress lets CheckLinks reach httptest servers on loopback
+func allowAnyAddress(netip.Addr) error { return nil }
+
+// linkHistory serves one conversations.history page whose messages link to urls
+func linkHistory(urls ...string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		messages := make([]map[string]interface{}, 0, len(urls))
+		for i, u := range urls {
+			messages = append(messages, map[string]interface{}{
+				"type": "message", "user": "U111", "text": "<" + u + ">", "ts": fmt.Sprintf("1700000%03d.000001", len(urls)-i),
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": true, "messages": messages})
+	}
+}
+
+func TestCheckLinks(t *testing.T) {
+	var heads atomic.Int32
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("method: got %s, want HEAD", r.Method)
+		}
+		heads.Add(1)
+		if r.URL.Path == "/gone" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer site.Close()
+
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	mock.addHandler("/conversations.history", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"type": "message", "user": "U111", "text": "docs: <" + site.URL + "/docs|the docs>", "ts": "1700000300.000001"},
+				{"type": "message", "user": "U222", "text": "old: <" + site.URL + "/gone> and <https://example.slack.com/archives/C1/p1>", "ts": "1700000200.000001"},
+				{"type": "message", "user": "U111", "text": "again <" + site.URL + "/docs>", "ts": "1700000100.000001"},
+			},
+			"has_more": false,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+	client.linkAddressCheck = allowAnyAddress
+
+	output, err := client.CheckLinks(context.Background(), CheckLinksInput{Channel: "C123456789"})
+	if err != nil {
+		t.Fatalf("CheckLinks failed: %v", err)
+	}
+
+	want := []LinkStatus{
+		{URL: site.URL + "/docs", Status: LinkReachable, StatusCode: 200, MessageTimestamp: "1700000300.000001"},
+		{URL: site.URL + "/gone", Status: LinkBroken, StatusCode: 404, MessageTimestamp: "1700000200.000001"},
+		{URL: site.URL + "/docs", Status: LinkReachable, StatusCode: 200, MessageTimestamp: "1700000100.000001"},
+	}
+	if len(output.Links) != len(want) {
+		t.Fatalf("Links: got %+v, want %+v", output.Links, want)
+	}
+	for i := range want {
+		if output.Links[i] != want[i] {
+			t.Errorf("Links[%d]: got %+v, want %+v", i, output.Links[i], want[i])
+		}
+	}
+	if output.TotalCount != 3 || output.BrokenCount != 1 {
+		t.Errorf("counts: got total=%d broken=%d, want total=3 broken=1", output.TotalCount, output.BrokenCount)
+	}
+	if got := heads.Load(); got != 2 {
+		t.Errorf("HEAD requests: got %d, want 2 (one per distinct URL)", got)
+	}
+}
+
+func TestIsSlackURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://slack.com/help", true},
+		{"https://acme.slack.com/archives/C1/p1", true},
+		{"https://files.slack-edge.com/x.png", true},
+		{"https://example.com/slack.com", false},
+		{"https://notslack.com", false},
+	}
+	for _, tt := range tests {
+		if got := isSlackURL(tt.url); got != tt.want {
+			t.Errorf("isSlackURL(%q): got %v, want %v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestCheckLinks_RefusesNonPublicAddresses(t *testing.T) {
+	var requests atomic.Int32
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if r.URL.Path == "/redirect" {
+			// 127.0.0.2 is loopback too, but outside what the check below allows
+			http.Redirect(w, r, "http://127.0.0.2:1/", http.StatusFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer site.Close()
+
+	mock := newMockSlackServer()
+	defer mock.close()
+	mock.addHandler("/conversations.history", linkHistory(site.URL+"/direct", site.URL+"/redirect"))
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	output, err := client.CheckLinks(context.Background(), CheckLinksInput{Channel: "C123456789"})
+	if err != nil {
+		t.Fatalf("CheckLinks failed: %v", err)
+	}
+	if output.BrokenCount != 2 || requests.Load() != 0 {
+		t.Errorf("default check: got %d broken and %d requests, want 2 and 0", output.BrokenCount, requests.Load())
+	}
+	for _, link := range output.Links {
+		if !strings.Contains(link.Error, errNonPublicAddress.Error()) {
+			t.Errorf("%s: got error %q, want a non-public address refusal", link.URL, link.Error)
+		}
+	}
+
+	client.linkAddressCheck = func(addr netip.Addr) error {
+		if addr != netip.MustParseAddr("127.0.0.1") {
+			return errNonPublicAddress
+		}
+		return nil
+	}
+	output, err = client.CheckLinks(context.Background(), CheckLinksInput{Channel: "C123456789"})
+	if err != nil {
+		t.Fatalf("CheckLinks failed: %v", err)
+	}
+	if got := output.Links[0]; got.Status != LinkReachable {
+		t.Errorf("direct link: got %+v, want reachable", got)
+	}
+	if got := output.Links[1]; got.Status != LinkBroken || !strings.Contains(got.Error, errNonPublicAddress.Error()) {
+		t.Errorf("redirect to a refused address: got %+v, want broken with a refusal", got)
+	}
+}
+
+func TestCheckLinks_LimitsRedirects(t *testing.T) {
+	var hops atomic.Int32
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hops.Add(1)
+		http.Redirect(w, r, "/loop", http.StatusFound)
+	}))
+	defer site.Close()
+
+	mock := newMockSlackServer()
+	defer mock.close()
+	mock.addHandler("/conversations.history", linkHistory(site.URL+"/loop"))
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+	client.linkAddressCheck = allowAnyAddress
+
+	output, err := client.CheckLinks(context.Background(), CheckLinksInput{Channel: "C123456789"})
+	if err != nil {
+		t.Fatalf("CheckLinks failed: %v", err)
+	}
+	if got := output.Links[0]; got.Status != LinkBroken || !strings.Contains(got.Error, "redirects") {
+		t.Errorf("redirect loop: got %+v, want broken after too many redirects", got)
+	}
+	if got := hops.Load(); got != maxLinkRedirects {
+		t.Errorf("requests: got %d, want %d", got, maxLinkRedirects)
+	}
+}
+
+func TestCheckLinks_FallsBackToGet(t *testing.T) {
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer site.Close()
+
+	mock := newMockSlackServer()
+	defer mock.close()
+	mock.addHandler("/conversations.history", linkHistory(site.URL+"/page"))
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+	client.linkAddressCheck = allowAnyAddress
+
+	output, err := client.CheckLinks(context.Background(), CheckLinksInput{Channel: "C123456789"})
+	if err != nil {
+		t.Fatalf("CheckLinks failed: %v", err)
+	}
+	if got := output.Links[0]; got.Status != LinkReachable || got.StatusCode != http.StatusOK {
+		t.Errorf("HEAD-rejecting server: got %+v, want reachable via GET", got)
+	}
+}
+
+func TestCheckLinks_CapsDistinctURLs(t *testing.T) {
+	var requests atomic.Int32
+	site := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer site.Close()
+
+	urls := make([]string, maxLinkChecks+2)
+	for i := range urls {
+		urls[i] = fmt.Sprintf("%s/%d", site.URL, i)
+	}
+	mock := newMockSlackServer()
+	defer mock.close()
+	mock.addHandler("/conversations.history", linkHistory(urls...))
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+	client.linkAddressCheck = allowAnyAddress
+	client.linkCheckTimeout = 5 * time.Second
+
+	output, err := client.CheckLinks(context.Background(), CheckLinksInput{Channel: "C123456789"})
+	if err != nil {
+		t.Fatalf("CheckLinks failed: %v", err)
+	}
+	if got := requests.Load(); got != maxLinkChecks {
+		t.Errorf("requests: got %d, want %d", got, maxLinkChecks)
+	}
+	if output.SkippedCount != 2 || output.Links[len(urls)-1].Status != LinkSkipped {
+		t.Errorf("skipped: got %d (last link %+v), want 2", output.SkippedCount, output.Links[len(urls)-1])
+	}
+}
+
+func TestRequirePublicAddress(t *testing.T) {
+	tests := []struct {
+		addr    string
+		wantErr bool
+	}{
+		{"93.184.216.34", false},
+		{"2606:2800:220:1:248:1893:25c8:1946", false},
+		{"127.0.0.1", true},
+		{"::1", true},
+		{"10.1.2.3", true},
+		{"172.16.0.1", true},
+		{"192.168.1.1", true},
+		{"169.254.169.254", true},
+		{"fe80::1", true},
+		{"fd00::1", true},
+		{"100.64.0.1", true},
+		{"0.0.0.0", true},
+		{"224.0.0.1", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			err := requirePublicAddress(netip.MustParseAddr(tt.addr))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("requirePublicAddress(%s): got err=%v, wantErr %v", tt.addr, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, errNonPublicAddress) {
+				t.Errorf("error %v does not wrap errNonPublicAddress", err)
+			}
+		})
+	}
+}
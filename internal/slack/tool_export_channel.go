@@ -2,43 +2,119 @@ package slack
 
 import (
 	"bufio"
+	"bytes"
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/slack-go/slack"
+	"go.uber.org/zap"
 )
 
 // ExportChannelInput defines input for exporting channel history
 type ExportChannelInput struct {
 	Channel string `json:"channel" jsonschema:"Channel ID or name"`
-	Oldest  string `json:"oldest,omitempty" jsonschema:"Start of time range (Unix timestamp)"`
-	Latest  string `json:"latest,omitempty" jsonschema:"End of time range (Unix timestamp)"`
+	Oldest  string `json:"oldest,omitempty" jsonschema:"Start of time range: Unix timestamp, RFC3339 time, YYYY-MM-DD date, or how long ago (e.g., 7d, 24h)"`
+	Latest  string `json:"latest,omitempty" jsonschema:"End of time range: Unix timestamp, RFC3339 time, YYYY-MM-DD date, or how long ago (e.g., 1d)"`
+
+	IncludeShared     bool `json:"include_shared,omitempty" jsonschema:"Include the content of shared/forwarded messages"`
+	VerifyReplyCounts bool `json:"verify_reply_counts,omitempty" jsonschema:"Report threads whose reply_count differs from the number of replies actually fetched"`
+	SplitByDay        bool `json:"split_by_day,omitempty" jsonschema:"Write one file per calendar day, with thread replies following their parent"`
+	Resume            bool `json:"resume,omitempty" jsonschema:"Continue an interrupted export of the same channel and time range instead of starting over. The include_shared, include_permalinks, include_blocks and exclude_bots settings must match the interrupted run. Relative bounds such as 7d move with the clock, so resume needs absolute ones. An export interrupted while writing thread files skips the history but fetches every thread again"`
+	IncludePermalinks bool `json:"include_permalinks,omitempty" jsonschema:"Add a permalink to every message. Costs one extra API call per message, so exports are much slower"`
+	IncludeBlocks     bool `json:"include_blocks,omitempty" jsonschema:"Add each message's raw Block Kit blocks and attachments JSON, for rebuilding rich content such as app unfurls"`
+	ExcludeBots       bool `json:"exclude_bots,omitempty" jsonschema:"Leave out messages posted by bots and apps, along with the threads under them (included by default)"`
+	Estimate          bool `json:"estimate,omitempty" jsonschema:"Count what an export would contain without writing any files. Thread replies are counted from each parent's reply_count; reactions and users cover top-level messages only"`
+
+	OutputPath string `json:"output_path,omitempty" jsonschema:"Absolute path to write the export file to instead of the responses directory. Must be inside the directory the operator set with SLACK_EXPORT_DIR. Thread files still go to the responses directory"`
+	Overwrite  bool   `json:"overwrite,omitempty" jsonschema:"Replace an existing file at output_path instead of failing"`
+
+	WorkspaceSelector
 }
 
+// ReplyCountDiscrepancy records a thread whose reported reply count did not
+// match the replies fetched for it
+type ReplyCountDiscrepancy struct {
+	Timestamp string `json:"timestamp"`
+	Reported  int    `json:"reported"`
+	Actual    int    `json:"actual"`
+}
+
+// UserActivity counts the messages one user posted
+type UserActivity struct {
+	User  string `json:"user"`
+	Name  string `json:"name,omitempty"`
+	Count int    `json:"count"`
+}
+
+// leaderboardSize is how many entries TopReactions and TopUsers hold
+const leaderboardSize = 5
+
 // exportStats tracks statistics during channel export
 type exportStats struct {
 	messageCount  int
 	threadCount   int
 	reactionCount int
-	uniqueUsers   map[string]bool
+	reactions     map[string]int
+	userPosts     map[string]int
+	discrepancies []ReplyCountDiscrepancy
 }
 
 func newExportStats() *exportStats {
-	return &exportStats{uniqueUsers: make(map[string]bool)}
+	return &exportStats{
+		reactions: make(map[string]int),
+		userPosts: make(map[string]int),
+	}
 }
 
 func (s *exportStats) addReactions(reactions []slack.ItemReaction) {
 	for _, r := range reactions {
 		s.reactionCount += r.Count
+		s.reactions[r.Name] += r.Count
 	}
 }
 
+// trackUser records one message posted by userID
 func (s *exportStats) trackUser(userID string) {
-	s.uniqueUsers[userID] = true
+	s.userPosts[userID]++
+}
+
+// topReactions returns the n most used reactions, most used first
+func (s *exportStats) topReactions(n int) []ReactionInfo {
+	top := make([]ReactionInfo, 0, len(s.reactions))
+	for name, count := range s.reactions {
+		top = append(top, ReactionInfo{Name: name, Count: count})
+	}
+	slices.SortFunc(top, func(a, b ReactionInfo) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Name, b.Name))
+	})
+	return top[:min(n, len(top))]
+}
+
+// topUsers returns the n users who posted the most messages, most active
+// first. Messages without a user, such as most bot posts, are not counted.
+func (s *exportStats) topUsers(n int, getUserName func(string) string) []UserActivity {
+	top := make([]UserActivity, 0, len(s.userPosts))
+	for user, count := range s.userPosts {
+		if user != "" {
+			top = append(top, UserActivity{User: user, Count: count})
+		}
+	}
+	slices.SortFunc(top, func(a, b UserActivity) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.User, b.User))
+	})
+	top = top[:min(n, len(top))]
+	for i := range top {
+		top[i].Name = getUserName(top[i].User)
+	}
+	return top
 }
 
 // processReactions converts Slack reactions to export format
@@ -53,211 +129,684 @@ func processReactions(reactions []slack.ItemReaction) []ReactionInfo {
 	return result
 }
 
-// buildMessageInfo converts a Slack message to export format
-func buildMessageInfo(msg slack.Message, threadTs string, userName string) MessageInfo {
-	return MessageInfo{
-		Timestamp:        msg.Timestamp,
-		TimestampDisplay: formatSlackTimestamp(msg.Timestamp),
-		User:             msg.User,
-		UserName:         userName,
-		Text:             msg.Text,
-		ThreadTimestamp:  threadTs,
-		ReplyCount:       msg.ReplyCount,
-		Reactions:        processReactions(msg.Reactions),
+// buildMessageInfo converts a Slack message to export format, formatting
+// its timestamps in loc
+func buildMessageInfo(msg slack.Message, threadTs string, userName string, loc *time.Location) MessageInfo {
+	info := MessageInfo{
+		Timestamp:          newTimestamp(msg.Timestamp, loc),
+		RawTimestamp:       msg.Timestamp,
+		User:               msg.User,
+		UserName:           userName,
+		Text:               msg.Text,
+		ThreadTimestamp:    newTimestamp(threadTs, loc),
+		RawThreadTimestamp: threadTs,
+		ReplyCount:         msg.ReplyCount,
+		Reactions:          processReactions(msg.Reactions),
+		Edited:             editedInfo(msg, loc),
+	}
+	info.ReactionSummary = reactionSummary(info.Reactions)
+	applyFileSubtype(&info, msg)
+	return info
+}
+
+// buildExportMessage converts a Slack message to export format, applying export options.
+// A permalink that cannot be fetched is logged and left blank rather than failing the export.
+func (c *Service) buildExportMessage(ctx context.Context, channelID string, msg slack.Message, threadTs string, userName string, input ExportChannelInput) MessageInfo {
+	info := buildMessageInfo(msg, threadTs, userName, c.displayLocation())
+	// Bot notifications often carry all their content in blocks
+	if info.Text == "" {
+		info.Text = renderBlocks(msg.Blocks)
+	}
+	if input.IncludeShared {
+		info.Shared = extractSharedMessage(msg)
 	}
+	if input.IncludeBlocks {
+		c.attachRawContent(&info, msg)
+	}
+	if input.IncludePermalinks {
+		permalink, err := c.api.GetPermalinkContext(ctx, &slack.PermalinkParameters{
+			Channel: channelID,
+			Ts:      msg.Timestamp,
+		})
+		info.Permalink = permalink
+		if err != nil {
+			c.logger.Warn("Failed to get permalink for export",
+				zap.String("ts", msg.Timestamp),
+				zap.Error(err))
+		}
+	}
+	return info
 }
 
-// writeThreadFile writes a complete thread (parent + replies) to a separate file
+// attachRawContent copies msg's blocks and attachments into info as raw JSON.
+// Content that fails to marshal is logged and left out.
+func (c *Service) attachRawContent(info *MessageInfo, msg slack.Message) {
+	if len(msg.Blocks.BlockSet) > 0 {
+		b, err := json.Marshal(msg.Blocks)
+		if err != nil {
+			c.logger.Warn("Failed to marshal message blocks", zap.String("ts", msg.Timestamp), zap.Error(err))
+		} else {
+			info.Blocks = b
+		}
+	}
+	if len(msg.Attachments) > 0 {
+		b, err := json.Marshal(msg.Attachments)
+		if err != nil {
+			c.logger.Warn("Failed to marshal message attachments", zap.String("ts", msg.Timestamp), zap.Error(err))
+		} else {
+			info.Attachments = b
+		}
+	}
+}
+
+// writeThreadFile writes a complete thread (parent + replies) to a separate
+// file. It also returns how many replies Slack returned, including any left
+// out by exclude_bots, for comparing with the parent's reply_count.
 func (c *Service) writeThreadFile(
 	ctx context.Context,
 	channelID string,
 	parentMsg slack.Message,
-	getUserName func(string) string,
+	input ExportChannelInput,
+	getUserName func(slack.Message) string,
 	stats *exportStats,
-) (FileRef, error) {
+) (FileRef, int, error) {
 	parentTs := parentMsg.Timestamp
 	filename := fmt.Sprintf("export-%s-thread-%s.jsonl", channelID, parentTs)
 
-	return c.responses.WriteJSONLinesNamed(filename, func(jw JSONLineWriter) error {
-		stats.trackUser(parentMsg.User)
-		stats.addReactions(parentMsg.Reactions)
-		if err := jw.WriteLine(buildMessageInfo(parentMsg, "", getUserName(parentMsg.User))); err != nil {
+	// The parent was already counted in the history pass; only replies are new here
+	fetched := 0
+	ref, err := c.responses.WriteJSONLinesNamed(filename, func(jw JSONLineWriter) error {
+		if err := jw.WriteLine(c.buildExportMessage(ctx, channelID, parentMsg, "", getUserName(parentMsg), input)); err != nil {
 			return err
 		}
 
-		cursor := ""
-		for {
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			default:
+		return c.forEachThreadReply(ctx, channelID, parentTs, func(reply slack.Message) error {
+			fetched++
+			if input.ExcludeBots && isBotMessage(reply) {
+				return nil
 			}
-
-			var replies []slack.Message
-			var hasMore bool
-			err := withRetry(ctx, c.logger, func() error {
-				var err error
-				replies, hasMore, cursor, err = c.api.GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
-					ChannelID: channelID,
-					Timestamp: parentTs,
-					Cursor:    cursor,
-					Limit:     200,
-				})
+			stats.trackUser(reply.User)
+			stats.addReactions(reply.Reactions)
+			if err := jw.WriteLine(c.buildExportMessage(ctx, channelID, reply, parentTs, getUserName(reply), input)); err != nil {
 				return err
-			})
-			if err != nil {
-				return fmt.Errorf("failed to get thread replies: %w", err)
 			}
+			stats.messageCount++
+			return nil
+		})
+	})
+	return ref, fetched, err
+}
 
-			for _, reply := range replies {
-				if reply.Timestamp == parentTs {
-					continue
-				}
+// defaultThreadPageSize is the conversations.replies page size used when Config.ThreadPageSize is zero
+const defaultThreadPageSize = 200
 
-				stats.trackUser(reply.User)
-				stats.addReactions(reply.Reactions)
+// forEachThreadReply pages through a thread's replies, calling fn for each
+// reply in order. The parent message itself is skipped, as is any message
+// conversations.replies repeats on a later page.
+func (c *Service) forEachThreadReply(ctx context.Context, channelID, parentTs string, fn func(slack.Message) error) error {
+	pageSize := c.threadPageSize
+	if pageSize <= 0 {
+		pageSize = defaultThreadPageSize
+	}
 
-				replyMsg := buildMessageInfo(reply, parentTs, getUserName(reply.User))
-				if err := jw.WriteLine(replyMsg); err != nil {
-					return err
-				}
-				stats.messageCount++
-			}
+	seen := map[string]bool{parentTs: true}
+	cursor := ""
+	for page := 1; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("thread %s interrupted before page %d: %w", parentTs, page, err)
+		}
+
+		replies, hasMore, next, err := c.api.GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+			ChannelID: channelID,
+			Timestamp: parentTs,
+			Cursor:    cursor,
+			Limit:     pageSize,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get thread %s replies (page %d): %w", parentTs, page, err)
+		}
 
-			if !hasMore || cursor == "" {
-				break
+		for _, reply := range replies {
+			if seen[reply.Timestamp] {
+				continue
+			}
+			seen[reply.Timestamp] = true
+			if err := fn(reply); err != nil {
+				return err
 			}
 		}
-		return nil
+
+		cursor = next
+		if !hasMore || cursor == "" {
+			return nil
+		}
+	}
+}
+
+// checkReplyCount compares a thread parent's reported reply count with the
+// number of replies fetched, recording any mismatch without failing the export.
+func (c *Service) checkReplyCount(parent slack.Message, actual int, stats *exportStats) {
+	if parent.ReplyCount == actual {
+		return
+	}
+	c.logger.Warn("Thread reply count mismatch",
+		zap.String("thread_ts", parent.Timestamp),
+		zap.Int("reported", parent.ReplyCount),
+		zap.Int("actual", actual))
+	stats.discrepancies = append(stats.discrepancies, ReplyCountDiscrepancy{
+		Timestamp: parent.Timestamp,
+		Reported:  parent.ReplyCount,
+		Actual:    actual,
 	})
 }
 
 // ExportChannelOutput contains export statistics and file reference
 type ExportChannelOutput struct {
-	File          FileRef   `json:"file"`
+	File          *FileRef  `json:"file,omitempty"`
+	Files         []FileRef `json:"files,omitempty"`
 	ThreadFiles   []FileRef `json:"thread_files,omitempty"`
 	ChannelID     string    `json:"channel_id"`
 	MessageCount  int       `json:"message_count"`
 	ThreadCount   int       `json:"thread_count"`
 	ReactionCount int       `json:"reaction_count"`
 	UniqueUsers   int       `json:"unique_users"`
+
+	// TopReactions and TopUsers are the most used reactions and most
+	// active posters across the exported messages
+	TopReactions []ReactionInfo `json:"top_reactions,omitempty"`
+	TopUsers     []UserActivity `json:"top_users,omitempty"`
+
+	Discrepancies []ReplyCountDiscrepancy `json:"discrepancies,omitempty"`
+
+	// Estimated is set when the counts come from an estimate and no files were written
+	Estimated bool `json:"estimated,omitempty"`
 }
 
 // ExportChannel exports a channel's messages to JSON-lines format.
 func (c *Service) ExportChannel(ctx context.Context, input ExportChannelInput) (ExportChannelOutput, error) {
-	channelID, err := c.GetChannelID(input.Channel)
+	channelID, err := c.GetChannelID(ctx, input.Channel, messageChannelTypes...)
 	if err != nil {
 		return ExportChannelOutput{}, err
 	}
 
+	input.Oldest, input.Latest, err = parseTimeRange(input.Oldest, input.Latest, time.Now().In(c.displayLocation()))
+	if err != nil {
+		return ExportChannelOutput{}, err
+	}
+
+	if input.Estimate {
+		return c.estimateExport(ctx, channelID, input)
+	}
+
+	if input.OutputPath != "" {
+		if input.SplitByDay {
+			return ExportChannelOutput{}, fmt.Errorf("output_path cannot be combined with split_by_day")
+		}
+		if input.OutputPath, err = validateOutputPath(c.exportDir, input.OutputPath, input.Overwrite); err != nil {
+			return ExportChannelOutput{}, err
+		}
+	}
+
 	stats := newExportStats()
 	names := c.newUserNameCache(ctx)
 
-	ref, threadFiles, err := c.exportChannelTwoPass(ctx, channelID, input, names.Get, stats)
+	var output ExportChannelOutput
+	if input.SplitByDay {
+		output.Files, err = c.exportChannelByDay(ctx, channelID, input, names.Author, stats)
+	} else {
+		var ref FileRef
+		ref, output.ThreadFiles, err = c.exportChannelTwoPass(ctx, channelID, input, names.Author, stats)
+		output.File = &ref
+	}
 	if err != nil {
 		return ExportChannelOutput{}, err
 	}
 
+	output.ChannelID = channelID
+	output.MessageCount = stats.messageCount
+	output.ThreadCount = stats.threadCount
+	output.ReactionCount = stats.reactionCount
+	output.UniqueUsers = len(stats.userPosts)
+	output.TopReactions = stats.topReactions(leaderboardSize)
+	output.TopUsers = stats.topUsers(leaderboardSize, names.Get)
+	output.Discrepancies = stats.discrepancies
+	return output, nil
+}
+
+// estimateExport pages through channel history counting what an export would
+// contain, without fetching thread replies or writing files. Replies are
+// counted from each parent's reply_count, so their reactions and authors are
+// not included.
+func (c *Service) estimateExport(ctx context.Context, channelID string, input ExportChannelInput) (ExportChannelOutput, error) {
+	stats := newExportStats()
+	cursor := ""
+	for {
+		if err := ctx.Err(); err != nil {
+			return ExportChannelOutput{}, err
+		}
+
+		history, err := c.fetchHistoryPage(ctx, channelID, input, cursor)
+		if err != nil {
+			return ExportChannelOutput{}, err
+		}
+
+		for _, msg := range history.Messages {
+			if input.ExcludeBots && isBotMessage(msg) {
+				continue
+			}
+			stats.trackUser(msg.User)
+			stats.addReactions(msg.Reactions)
+			stats.messageCount += 1 + msg.ReplyCount
+			if msg.ReplyCount > 0 {
+				stats.threadCount++
+			}
+		}
+
+		if !history.HasMore || history.ResponseMetaData.NextCursor == "" {
+			break
+		}
+		cursor = history.ResponseMetaData.NextCursor
+	}
+
 	return ExportChannelOutput{
-		File:          ref,
-		ThreadFiles:   threadFiles,
 		ChannelID:     channelID,
 		MessageCount:  stats.messageCount,
 		ThreadCount:   stats.threadCount,
 		ReactionCount: stats.reactionCount,
-		UniqueUsers:   len(stats.uniqueUsers),
+		UniqueUsers:   len(stats.userPosts),
+		Estimated:     true,
 	}, nil
 }
 
+// fetchHistoryPage fetches one page of channel history for an export
+func (c *Service) fetchHistoryPage(ctx context.Context, channelID string, input ExportChannelInput, cursor string) (*slack.GetConversationHistoryResponse, error) {
+	history, err := c.api.GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+		ChannelID: channelID,
+		Cursor:    cursor,
+		Oldest:    input.Oldest,
+		Latest:    input.Latest,
+		Limit:     200,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get history: %w", err)
+	}
+	return history, nil
+}
+
+// validateOutputPath checks that path is an absolute, traversal-free file
+// path in a writable directory under root, following symlinks, and returns
+// it with its directory's symlinks resolved. An existing file is only
+// accepted when overwrite is set, and never if it is a symlink.
+func validateOutputPath(root, path string, overwrite bool) (string, error) {
+	if root == "" {
+		return "", fmt.Errorf("output_path is disabled; the operator must set SLACK_EXPORT_DIR to allow it")
+	}
+	if !filepath.IsAbs(path) {
+		return "", fmt.Errorf("output_path must be absolute: %q", path)
+	}
+	if slices.Contains(strings.Split(filepath.ToSlash(path), "/"), "..") {
+		return "", fmt.Errorf("output_path must not contain '..': %q", path)
+	}
+
+	realRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return "", fmt.Errorf("export directory %q: %w", root, err)
+	}
+	dir, err := filepath.EvalSymlinks(filepath.Dir(path))
+	if err != nil {
+		return "", fmt.Errorf("output_path directory does not exist: %w", err)
+	}
+	if rel, err := filepath.Rel(realRoot, dir); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("output_path must be inside the export directory %q: %q", root, path)
+	}
+	path = filepath.Join(dir, filepath.Base(path))
+
+	if fi, err := os.Lstat(path); err == nil {
+		switch {
+		case fi.IsDir():
+			return "", fmt.Errorf("output_path is a directory: %q", path)
+		case !fi.Mode().IsRegular():
+			return "", fmt.Errorf("output_path is not a regular file: %q", path)
+		case !overwrite:
+			return "", fmt.Errorf("output_path already exists; set overwrite to replace it: %q", path)
+		}
+	}
+
+	probe, err := os.CreateTemp(dir, ".export-probe-*")
+	if err != nil {
+		return "", fmt.Errorf("output_path directory is not writable: %w", err)
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+	return path, nil
+}
+
+// dayExportLine is a top-level message line awaiting its day file
+type dayExportLine struct {
+	ts   string
+	line json.RawMessage
+}
+
+// exportChannelByDay writes one file per calendar day in the display time
+// zone, named export-<channel>-YYYY-MM-DD.jsonl. Messages are chronological
+// within each file, and each thread's replies follow their parent.
+func (c *Service) exportChannelByDay(
+	ctx context.Context,
+	channelID string,
+	input ExportChannelInput,
+	getUserName func(slack.Message) string,
+	stats *exportStats,
+) (files []FileRef, err error) {
+	tmpPath, offsets, threadsToExport, err := c.writeHistoryToTempFile(ctx, c.responses.Dir(), channelID, input, getUserName, stats)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { c.finishHistory(channelID, input, tmpPath, err) }()
+
+	defer func() {
+		if err != nil {
+			for _, f := range files {
+				os.Remove(f.Path)
+			}
+		}
+	}()
+
+	parents := make(map[string]slack.Message, len(threadsToExport))
+	for _, msg := range threadsToExport {
+		parents[msg.Timestamp] = msg
+	}
+
+	tmpReader, err := os.Open(tmpPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reopen temp file: %w", err)
+	}
+	defer tmpReader.Close()
+
+	var day string
+	var pending []dayExportLine
+	flush := func() error {
+		if len(pending) == 0 {
+			return nil
+		}
+		filename := fmt.Sprintf("export-%s-%s.jsonl", channelID, day)
+		ref, err := c.responses.WriteJSONLinesNamed(filename, func(jw JSONLineWriter) error {
+			for _, p := range pending {
+				if err := jw.WriteLine(p.line); err != nil {
+					return err
+				}
+				parent, ok := parents[p.ts]
+				if !ok {
+					continue
+				}
+				replies := 0
+				err := c.forEachThreadReply(ctx, channelID, parent.Timestamp, func(reply slack.Message) error {
+					replies++
+					if input.ExcludeBots && isBotMessage(reply) {
+						return nil
+					}
+					stats.trackUser(reply.User)
+					stats.addReactions(reply.Reactions)
+					if err := jw.WriteLine(c.buildExportMessage(ctx, channelID, reply, parent.Timestamp, getUserName(reply), input)); err != nil {
+						return err
+					}
+					stats.messageCount++
+					return nil
+				})
+				if err != nil {
+					return err
+				}
+				if input.VerifyReplyCounts {
+					c.checkReplyCount(parent, replies, stats)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to write day file: %w", err)
+		}
+		files = append(files, ref)
+		pending = pending[:0]
+		return nil
+	}
+
+	lines, err := newOffsetLines(tmpReader, offsets)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := c.displayLocation()
+
+	// History was written newest first, so walk the offsets backwards.
+	for i := len(offsets) - 1; i >= 0; i-- {
+		if err := ctx.Err(); err != nil {
+			return files, err
+		}
+		line, err := lines.line(i)
+		if err != nil {
+			return files, err
+		}
+		// pending outlives the shared read buffer
+		line = bytes.Clone(line)
+
+		var msg struct {
+			RawTimestamp string `json:"raw_timestamp"`
+		}
+		if err := json.Unmarshal(line, &msg); err != nil {
+			return files, fmt.Errorf("failed to decode message: %w", err)
+		}
+		sec, err := parseUnixSeconds(msg.RawTimestamp)
+		if err != nil {
+			return files, fmt.Errorf("invalid message timestamp %q: %w", msg.RawTimestamp, err)
+		}
+
+		if d := time.Unix(sec, 0).In(loc).Format(time.DateOnly); d != day {
+			if err := flush(); err != nil {
+				return files, err
+			}
+			day = d
+		}
+		pending = append(pending, dayExportLine{ts: msg.RawTimestamp, line: line})
+	}
+
+	if err := flush(); err != nil {
+		return files, err
+	}
+	return files, nil
+}
+
 // exportChannelTwoPass implements the two-pass export for chronological ordering.
 func (c *Service) exportChannelTwoPass(
 	ctx context.Context,
 	channelID string,
 	input ExportChannelInput,
-	getUserName func(string) string,
+	getUserName func(slack.Message) string,
 	stats *exportStats,
-) (FileRef, []FileRef, error) {
+) (ref FileRef, threadFiles []FileRef, err error) {
 	dir := c.responses.Dir()
 
 	tmpPath, offsets, threadsToExport, err := c.writeHistoryToTempFile(ctx, dir, channelID, input, getUserName, stats)
 	if err != nil {
 		return FileRef{}, nil, err
 	}
-	defer os.Remove(tmpPath)
+	defer func() { c.finishHistory(channelID, input, tmpPath, err) }()
 
-	var threadFiles []FileRef
+	// An interrupted export must not leave files that look complete.
+	defer func() {
+		if err != nil {
+			for _, f := range threadFiles {
+				os.Remove(f.Path)
+			}
+		}
+	}()
 
 	for _, msg := range threadsToExport {
-		threadRef, err := c.writeThreadFile(ctx, channelID, msg, getUserName, stats)
+		threadRef, replies, err := c.writeThreadFile(ctx, channelID, msg, input, getUserName, stats)
 		if err != nil {
-			return FileRef{}, nil, fmt.Errorf("failed to write thread file: %w", err)
+			return FileRef{}, threadFiles, fmt.Errorf("failed to write thread file: %w", err)
 		}
-		threadFiles = append(threadFiles, threadRef)
-	}
-
-	if len(offsets) == 0 {
-		filename := fmt.Sprintf("export-%s-%d.jsonl", channelID, time.Now().UnixNano())
-		filePath := filepath.Join(dir, filename)
-		if err := os.WriteFile(filePath, nil, 0o644); err != nil {
-			return FileRef{}, nil, fmt.Errorf("failed to create empty file: %w", err)
+		if input.VerifyReplyCounts {
+			c.checkReplyCount(msg, replies, stats)
 		}
-		return FileRef{Path: filePath, Name: filename, Bytes: 0, Lines: 0}, threadFiles, nil
+		threadFiles = append(threadFiles, threadRef)
 	}
 
 	filename := fmt.Sprintf("export-%s-%d.jsonl", channelID, time.Now().UnixNano())
 	filePath := filepath.Join(dir, filename)
-	finalFile, err := os.Create(filePath)
-	if err != nil {
-		return FileRef{}, nil, fmt.Errorf("failed to create final file: %w", err)
+	uri := ResponseURIPrefix + filename
+	if input.OutputPath != "" {
+		filePath = input.OutputPath
+		filename = filepath.Base(filePath)
+		uri = ""
 	}
-	defer finalFile.Close()
 
-	tmpReader, err := os.Open(tmpPath)
+	finalFile, err := os.CreateTemp(filepath.Dir(filePath), filename+".tmp-*")
 	if err != nil {
-		return FileRef{}, nil, fmt.Errorf("failed to reopen temp file: %w", err)
+		return FileRef{}, threadFiles, fmt.Errorf("failed to create final file: %w", err)
 	}
-	defer tmpReader.Close()
+	defer func() {
+		finalFile.Close()
+		if err != nil {
+			os.Remove(finalFile.Name())
+		}
+	}()
 
-	if err := reverseCopyLines(tmpReader, finalFile, offsets); err != nil {
-		return FileRef{}, nil, err
+	if len(offsets) > 0 {
+		tmpReader, err := os.Open(tmpPath)
+		if err != nil {
+			return FileRef{}, threadFiles, fmt.Errorf("failed to reopen temp file: %w", err)
+		}
+		defer tmpReader.Close()
+
+		if err := reverseCopyLines(ctx, tmpReader, finalFile, offsets); err != nil {
+			return FileRef{}, threadFiles, err
+		}
 	}
 
-	fi, err := finalFile.Stat()
+	size, err := commitTempFile(finalFile, filePath)
 	if err != nil {
-		return FileRef{}, nil, fmt.Errorf("failed to stat final file: %w", err)
+		return FileRef{}, threadFiles, err
 	}
 
 	return FileRef{
 		Path:  filePath,
 		Name:  filename,
-		Bytes: fi.Size(),
+		URI:   uri,
+		Bytes: size,
 		Lines: len(offsets),
 	}, threadFiles, nil
 }
 
+// exportProgressInterval is how many history messages pass between progress updates
+const exportProgressInterval = 1000
+
 // writeHistoryToTempFile fetches channel history and writes messages to a temp file.
+// When checkpointing is enabled, progress is saved after each page so that an
+// interrupted run can be continued with input.Resume.
 func (c *Service) writeHistoryToTempFile(
 	ctx context.Context,
 	dir string,
 	channelID string,
 	input ExportChannelInput,
-	getUserName func(string) string,
+	getUserName func(slack.Message) string,
 	stats *exportStats,
 ) (tmpPath string, offsets []int64, threadsToExport []slack.Message, err error) {
-	tmpFile, err := os.CreateTemp(dir, "export-tmp-*.jsonl")
-	if err != nil {
-		return "", nil, nil, fmt.Errorf("failed to create temp file: %w", err)
+	cpPath := c.checkpointPath(channelID, input)
+
+	var cp *exportCheckpoint
+	if cpPath != "" {
+		if input.Resume {
+			if cp, err = loadCheckpoint(cpPath); err != nil {
+				return "", nil, nil, err
+			}
+		} else {
+			discardCheckpoint(cpPath)
+		}
+	}
+	if cp != nil && cp.Options != exportOptionsFrom(input) {
+		return "", nil, nil, fmt.Errorf("checkpoint was saved with different export options (%+v); resume with the same options or export again without resume", cp.Options)
+	}
+
+	var tmpFile *os.File
+	var pos int64
+	cursor := ""
+	// Thread parents are only written out when there is a checkpoint to
+	// point at them.
+	var threadsFile *os.File
+	var threadPos int64
+	if cp != nil {
+		tmpFile, offsets, err = cp.reopen()
+		if err != nil {
+			return "", nil, nil, err
+		}
+		threadsFile, threadsToExport, err = cp.reopenThreads()
+		if err != nil {
+			tmpFile.Close()
+			return "", nil, nil, err
+		}
+		cp.restore(stats)
+		pos = cp.Bytes
+		threadPos = cp.ThreadBytes
+		cursor = cp.Cursor
+		c.logger.Info("Resuming export from checkpoint",
+			zap.String("channel", channelID),
+			zap.Int("messages", len(offsets)),
+			zap.Bool("history_complete", cp.Complete))
+	} else {
+		tmpFile, err = os.CreateTemp(dir, "export-tmp-*.jsonl")
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("failed to create temp file: %w", err)
+		}
+		if cpPath != "" {
+			threadsFile, err = os.CreateTemp(dir, "export-threads-*.jsonl")
+			if err != nil {
+				tmpFile.Close()
+				os.Remove(tmpFile.Name())
+				return "", nil, nil, fmt.Errorf("failed to create temp file: %w", err)
+			}
+		}
 	}
+
+	// Once a checkpoint references the temp files they must survive failures.
+	checkpointed := cp != nil
 	defer func() {
 		tmpFile.Close()
-		if err != nil {
+		if threadsFile != nil {
+			threadsFile.Close()
+		}
+		if err != nil && !checkpointed {
 			os.Remove(tmpFile.Name())
+			if threadsFile != nil {
+				os.Remove(threadsFile.Name())
+			}
 		}
 	}()
 
+	if cp != nil && cp.Complete {
+		return tmpFile.Name(), offsets, threadsToExport, nil
+	}
+
 	bw := bufio.NewWriter(tmpFile)
-	var pos int64
-	cursor := ""
+	var threadsOut *bufio.Writer
+	if threadsFile != nil {
+		threadsOut = bufio.NewWriter(threadsFile)
+	}
+
+	// save flushes both files and records how far the export got
+	save := func(cursor string, complete bool) error {
+		if err := bw.Flush(); err != nil {
+			return fmt.Errorf("failed to flush temp file: %w", err)
+		}
+		if err := threadsOut.Flush(); err != nil {
+			return fmt.Errorf("failed to flush temp file: %w", err)
+		}
+		cp := newExportCheckpoint(channelID, input, cursor, tmpFile.Name(), pos, threadsFile.Name(), threadPos, stats)
+		cp.Complete = complete
+		if err := saveCheckpoint(cpPath, cp); err != nil {
+			return err
+		}
+		checkpointed = true
+		return nil
+	}
 
 	for {
 		select {
@@ -267,26 +816,19 @@ func (c *Service) writeHistoryToTempFile(
 		}
 
 		var history *slack.GetConversationHistoryResponse
-		err = withRetry(ctx, c.logger, func() error {
-			var e error
-			history, e = c.api.GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
-				ChannelID: channelID,
-				Cursor:    cursor,
-				Oldest:    input.Oldest,
-				Latest:    input.Latest,
-				Limit:     200,
-			})
-			return e
-		})
+		history, err = c.fetchHistoryPage(ctx, channelID, input, cursor)
 		if err != nil {
-			return "", nil, nil, fmt.Errorf("failed to get history: %w", err)
+			return "", nil, nil, err
 		}
 
 		for _, msg := range history.Messages {
+			if input.ExcludeBots && isBotMessage(msg) {
+				continue
+			}
 			stats.trackUser(msg.User)
 			stats.addReactions(msg.Reactions)
 
-			exportMsg := buildMessageInfo(msg, "", getUserName(msg.User))
+			exportMsg := c.buildExportMessage(ctx, channelID, msg, "", getUserName(msg), input)
 			b, err := json.Marshal(exportMsg)
 			if err != nil {
 				return "", nil, nil, fmt.Errorf("failed to marshal message: %w", err)
@@ -307,6 +849,25 @@ func (c *Service) writeHistoryToTempFile(
 			if msg.ReplyCount > 0 {
 				stats.threadCount++
 				threadsToExport = append(threadsToExport, msg)
+				if threadsOut != nil {
+					b, err := json.Marshal(msg)
+					if err != nil {
+						return "", nil, nil, fmt.Errorf("failed to marshal thread parent: %w", err)
+					}
+					n, err := threadsOut.Write(append(b, '\n'))
+					if err != nil {
+						return "", nil, nil, err
+					}
+					threadPos += int64(n)
+				}
+			}
+
+			if stats.messageCount%exportProgressInterval == 0 {
+				c.logger.Debug("Export progress",
+					zap.String("channel", channelID),
+					zap.Int("messages", stats.messageCount),
+					zap.Int("threads", stats.threadCount))
+				reportProgress(ctx, fmt.Sprintf("fetched %d messages, %d threads", stats.messageCount, stats.threadCount), float64(stats.messageCount))
 			}
 		}
 
@@ -314,31 +875,56 @@ func (c *Service) writeHistoryToTempFile(
 			break
 		}
 		cursor = history.ResponseMetaData.NextCursor
+
+		if cpPath != "" {
+			if err = save(cursor, false); err != nil {
+				return "", nil, nil, err
+			}
+		}
 	}
 
-	if err = bw.Flush(); err != nil {
+	if cpPath != "" {
+		// Keep the checkpoint until the thread pass is done as well.
+		if err = save("", true); err != nil {
+			return "", nil, nil, err
+		}
+	} else if err = bw.Flush(); err != nil {
 		return "", nil, nil, fmt.Errorf("failed to flush temp file: %w", err)
 	}
 
 	return tmpFile.Name(), offsets, threadsToExport, nil
 }
 
+// finishHistory removes the history temp file once an export is done with
+// it. When checkpointing is enabled a failed export keeps the file and its
+// checkpoint so it can be resumed; a successful one discards both.
+func (c *Service) finishHistory(channelID string, input ExportChannelInput, tmpPath string, err error) {
+	cpPath := c.checkpointPath(channelID, input)
+	switch {
+	case cpPath == "":
+		os.Remove(tmpPath)
+	case err == nil:
+		discardCheckpoint(cpPath)
+	}
+}
+
 // reverseCopyLines copies lines from src to dst in reverse order using pre-recorded offsets.
-func reverseCopyLines(src *os.File, dst *os.File, offsets []int64) error {
+// It stops early if ctx is cancelled.
+func reverseCopyLines(ctx context.Context, src *os.File, dst *os.File, offsets []int64) error {
+	lines, err := newOffsetLines(src, offsets)
+	if err != nil {
+		return err
+	}
 	bw := bufio.NewWriter(dst)
 	for i := len(offsets) - 1; i >= 0; i-- {
-		if _, err := src.Seek(offsets[i], 0); err != nil {
-			return fmt.Errorf("failed to seek: %w", err)
-		}
-		scanner := bufio.NewScanner(src)
-		scanner.Buffer(make([]byte, 1024*1024), 10*1024*1024)
-		if !scanner.Scan() {
-			if err := scanner.Err(); err != nil {
-				return fmt.Errorf("failed to read line: %w", err)
-			}
-			continue
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		line, err := lines.line(i)
+		if err != nil {
+			return err
 		}
-		if _, err := bw.Write(scanner.Bytes()); err != nil {
+		if _, err := bw.Write(line); err != nil {
 			return err
 		}
 		if err := bw.WriteByte('\n'); err != nil {
@@ -350,3 +936,40 @@ func reverseCopyLines(src *os.File, dst *os.File, offsets []int64) error {
 	}
 	return nil
 }
+
+// offsetLines reads lines from a file whose line start offsets are already
+// known. Each line's length is the gap to the next offset (or to the end of
+// the file), so lines are read directly with no scanning and a single buffer
+// that grows to the longest line.
+type offsetLines struct {
+	src     io.ReaderAt
+	offsets []int64
+	size    int64
+	buf     []byte
+}
+
+func newOffsetLines(src *os.File, offsets []int64) (*offsetLines, error) {
+	fi, err := src.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat temp file: %w", err)
+	}
+	return &offsetLines{src: src, offsets: offsets, size: fi.Size()}, nil
+}
+
+// line returns line i without its trailing newline. The slice is only valid
+// until the next call.
+func (l *offsetLines) line(i int) ([]byte, error) {
+	start, end := l.offsets[i], l.size
+	if i+1 < len(l.offsets) {
+		end = l.offsets[i+1]
+	}
+	n := int(end - start)
+	if cap(l.buf) < n {
+		l.buf = make([]byte, n)
+	}
+	buf := l.buf[:n]
+	if _, err := l.src.ReadAt(buf, start); err != nil {
+		return nil, fmt.Errorf("failed to read line: %w", err)
+	}
+	return bytes.TrimSuffix(buf, []byte{'\n'}), nil
+}
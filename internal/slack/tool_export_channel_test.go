@@ -1,12 +1,18 @@
 package slack
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/slack-go/slack"
 )
@@ -64,13 +70,13 @@ func TestBuildMessageInfo(t *testing.T) {
 		},
 	}
 
-	got := buildMessageInfo(msg, "", "alice")
+	got := buildMessageInfo(msg, "", "alice", time.UTC)
 
-	if got.Timestamp != "1234567890.123456" {
-		t.Errorf("Timestamp: got %q, want %q", got.Timestamp, "1234567890.123456")
+	if got.Timestamp != "2009-02-13T23:31:30.123456Z" {
+		t.Errorf("Timestamp: got %q, want %q", got.Timestamp, "2009-02-13T23:31:30.123456Z")
 	}
-	if got.TimestampDisplay != "2009-02-13T23:31:30Z" {
-		t.Errorf("TimestampDisplay: got %q, want %q", got.TimestampDisplay, "2009-02-13T23:31:30Z")
+	if got.RawTimestamp != "1234567890.123456" {
+		t.Errorf("RawTimestamp: got %q, want %q", got.RawTimestamp, "1234567890.123456")
 	}
 	if got.User != "U123456789" {
 		t.Errorf("User: got %q, want %q", got.User, "U123456789")
@@ -92,6 +98,59 @@ func TestBuildMessageInfo(t *testing.T) {
 	}
 }
 
+func TestBuildExportMessage_ReactionSummary(t *testing.T) {
+	client := newServiceWithIndex(nil, nil, nil, nil)
+
+	tests := []struct {
+		name      string
+		reactions []slack.ItemReaction
+		want      string
+	}{
+		{"none", nil, ""},
+		{"standard emoji", []slack.ItemReaction{
+			{Name: "thumbsup", Count: 3},
+			{Name: "heart", Count: 2},
+		}, "👍3 ❤️2"},
+		{"custom emoji falls back to name", []slack.ItemReaction{
+			{Name: "+1", Count: 1},
+			{Name: "shipit", Count: 1},
+		}, "👍1 :shipit:1"},
+		{"skin tone variant", []slack.ItemReaction{
+			{Name: "+1::skin-tone-3", Count: 4},
+		}, "👍4"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg := slack.Message{Msg: slack.Msg{Timestamp: "1700000000.000100", Reactions: tt.reactions}}
+
+			got := client.buildExportMessage(context.Background(), "C123456789", msg, "", "", ExportChannelInput{})
+
+			if got.ReactionSummary != tt.want {
+				t.Errorf("ReactionSummary: got %q, want %q", got.ReactionSummary, tt.want)
+			}
+			if len(got.Reactions) != len(tt.reactions) {
+				t.Errorf("len(Reactions): got %d, want %d", len(got.Reactions), len(tt.reactions))
+			}
+		})
+	}
+}
+
+func TestBuildExportMessage_RendersBlocksForEmptyText(t *testing.T) {
+	client := newServiceWithIndex(nil, nil, nil, nil)
+	header := slack.NewHeaderBlock(slack.NewTextBlockObject(slack.PlainTextType, "Disk almost full", false, false))
+
+	msg := slack.Message{Msg: slack.Msg{Timestamp: "1700000000.000100", Blocks: slack.Blocks{BlockSet: []slack.Block{header}}}}
+	if got := client.buildExportMessage(context.Background(), "C123456789", msg, "", "", ExportChannelInput{}); got.Text != "Disk almost full" {
+		t.Errorf("Text from blocks: got %q, want %q", got.Text, "Disk almost full")
+	}
+
+	msg.Text = "Disk alert"
+	if got := client.buildExportMessage(context.Background(), "C123456789", msg, "", "", ExportChannelInput{}); got.Text != "Disk alert" {
+		t.Errorf("Text with top-level text: got %q, want %q", got.Text, "Disk alert")
+	}
+}
+
 func TestBuildMessageInfo_ThreadReply(t *testing.T) {
 	msg := slack.Message{
 		Msg: slack.Msg{
@@ -101,10 +160,13 @@ func TestBuildMessageInfo_ThreadReply(t *testing.T) {
 		},
 	}
 
-	got := buildMessageInfo(msg, "1234567890.123456", "bob")
+	got := buildMessageInfo(msg, "1234567890.123456", "bob", time.UTC)
 
-	if got.ThreadTimestamp != "1234567890.123456" {
-		t.Errorf("ThreadTimestamp: got %q, want %q", got.ThreadTimestamp, "1234567890.123456")
+	if got.ThreadTimestamp != "2009-02-13T23:31:30.123456Z" {
+		t.Errorf("ThreadTimestamp: got %q, want %q", got.ThreadTimestamp, "2009-02-13T23:31:30.123456Z")
+	}
+	if got.RawThreadTimestamp != "1234567890.123456" {
+		t.Errorf("RawThreadTimestamp: got %q, want %q", got.RawThreadTimestamp, "1234567890.123456")
 	}
 	if got.UserName != "bob" {
 		t.Errorf("UserName: got %q, want %q", got.UserName, "bob")
@@ -118,8 +180,8 @@ func TestExportStats(t *testing.T) {
 	stats.trackUser("U456")
 	stats.trackUser("U123")
 
-	if len(stats.uniqueUsers) != 2 {
-		t.Errorf("unique users: got %d, want 2", len(stats.uniqueUsers))
+	if len(stats.userPosts) != 2 {
+		t.Errorf("unique users: got %d, want 2", len(stats.userPosts))
 	}
 
 	stats.addReactions([]slack.ItemReaction{
@@ -138,6 +200,21 @@ func TestExportStats(t *testing.T) {
 	if stats.reactionCount != 6 {
 		t.Errorf("reactionCount after second add: got %d, want 6", stats.reactionCount)
 	}
+
+	stats.addReactions([]slack.ItemReaction{
+		{Name: "heart", Count: 2},
+	})
+
+	wantReactions := []ReactionInfo{{Name: "heart", Count: 4}, {Name: "thumbsup", Count: 3}}
+	if got := stats.topReactions(2); !slices.Equal(got, wantReactions) {
+		t.Errorf("topReactions: got %v, want %v", got, wantReactions)
+	}
+
+	names := map[string]string{"U123": "alice", "U456": "bob"}
+	wantUsers := []UserActivity{{User: "U123", Name: "alice", Count: 2}, {User: "U456", Name: "bob", Count: 1}}
+	if got := stats.topUsers(5, func(id string) string { return names[id] }); !slices.Equal(got, wantUsers) {
+		t.Errorf("topUsers: got %v, want %v", got, wantUsers)
+	}
 }
 
 func TestFormatSlackTimestamp(t *testing.T) {
@@ -153,7 +230,7 @@ func TestFormatSlackTimestamp(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := formatSlackTimestamp(tt.input)
+			got := formatSlackTimestamp(tt.input, time.UTC)
 			if got != tt.want {
 				t.Errorf("formatSlackTimestamp(%q): got %q, want %q", tt.input, got, tt.want)
 			}
@@ -278,11 +355,11 @@ func TestExportChannel_BasicMessages(t *testing.T) {
 		t.Errorf("First message UserName: got %q, want %q", msg.UserName, "alice")
 	}
 
-	if msg.Timestamp != "1704067200.000001" {
-		t.Errorf("Timestamp: got %q, want raw Slack ts", msg.Timestamp)
+	if msg.RawTimestamp != "1704067200.000001" {
+		t.Errorf("RawTimestamp: got %q, want raw Slack ts", msg.RawTimestamp)
 	}
-	if !strings.HasPrefix(msg.TimestampDisplay, "2024-") {
-		t.Errorf("TimestampDisplay not in ISO format: got %q", msg.TimestampDisplay)
+	if !strings.Contains(lines[0], `"timestamp":"2024-01-01T00:00:00.000001Z"`) {
+		t.Errorf("timestamp not in ISO format: got %s", lines[0])
 	}
 }
 
@@ -433,8 +510,233 @@ func TestExportChannel_WithThreads(t *testing.T) {
 		t.Fatalf("Failed to unmarshal second line: %v", err)
 	}
 
-	if reply.ThreadTimestamp != "1704067200.000001" {
-		t.Errorf("Reply ThreadTimestamp: got %q, want %q", reply.ThreadTimestamp, "1704067200.000001")
+	if !strings.Contains(threadLines[1], `"thread_ts":"2024-01-01T00:00:00.000001Z"`) {
+		t.Errorf("Reply thread_ts not in ISO format: got %s", threadLines[1])
+	}
+	if reply.RawThreadTimestamp != "1704067200.000001" {
+		t.Errorf("RawThreadTimestamp: got %q, want %q", reply.RawThreadTimestamp, "1704067200.000001")
+	}
+}
+
+func TestExportChannel_EstimateMatchesExport(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	mock.addHandler("/conversations.history", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"type": "message", "user": "U987654321", "text": "Standalone", "ts": "1704067300.000001"},
+				{
+					"type": "message", "user": "U123456789", "text": "Thread parent", "ts": "1704067200.000001", "reply_count": 2,
+					"reactions": []map[string]interface{}{{"name": "thumbsup", "count": 3, "users": []string{"U1", "U2", "U3"}}},
+				},
+			},
+			"has_more": false,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/conversations.replies", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"type": "message", "user": "U123456789", "text": "Thread parent", "ts": "1704067200.000001", "thread_ts": "1704067200.000001"},
+				{"type": "message", "user": "U987654321", "text": "First reply", "ts": "1704067201.000001", "thread_ts": "1704067200.000001"},
+				{"type": "message", "user": "U123456789", "text": "Second reply", "ts": "1704067202.000001", "thread_ts": "1704067200.000001"},
+			},
+			"has_more": false,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/users.info", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok":   true,
+			"user": map[string]interface{}{"id": "U123456789", "name": "alice"},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	estimate, err := client.ExportChannel(context.Background(), ExportChannelInput{Channel: "C123456789", Estimate: true})
+	if err != nil {
+		t.Fatalf("ExportChannel estimate failed: %v", err)
+	}
+	entries, err := os.ReadDir(responsesDir)
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("estimate wrote %d files, want 0", len(entries))
+	}
+	if !estimate.Estimated || estimate.File != nil || len(estimate.ThreadFiles) != 0 {
+		t.Errorf("estimate output should have no files and Estimated set: %+v", estimate)
+	}
+
+	export, err := client.ExportChannel(context.Background(), ExportChannelInput{Channel: "C123456789"})
+	if err != nil {
+		t.Fatalf("ExportChannel failed: %v", err)
+	}
+
+	if estimate.MessageCount != export.MessageCount {
+		t.Errorf("MessageCount: estimate %d, export %d", estimate.MessageCount, export.MessageCount)
+	}
+	if estimate.ThreadCount != export.ThreadCount {
+		t.Errorf("ThreadCount: estimate %d, export %d", estimate.ThreadCount, export.ThreadCount)
+	}
+	if estimate.ReactionCount != export.ReactionCount {
+		t.Errorf("ReactionCount: estimate %d, export %d", estimate.ReactionCount, export.ReactionCount)
+	}
+	if estimate.UniqueUsers != export.UniqueUsers {
+		t.Errorf("UniqueUsers: estimate %d, export %d", estimate.UniqueUsers, export.UniqueUsers)
+	}
+}
+
+func TestExportChannel_ThreadRepliesDeduplicated(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	mock.addHandler("/conversations.history", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"type": "message", "user": "U123456789", "text": "Thread parent", "ts": "1704067200.000001", "reply_count": 2},
+			},
+			"has_more": false,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	// The second page echoes the parent and repeats the last reply of page one
+	mock.addHandler("/conversations.replies", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		parent := map[string]interface{}{"type": "message", "user": "U123456789", "text": "Thread parent", "ts": "1704067200.000001", "thread_ts": "1704067200.000001"}
+		first := map[string]interface{}{"type": "message", "user": "U987654321", "text": "First reply", "ts": "1704067201.000001", "thread_ts": "1704067200.000001"}
+		second := map[string]interface{}{"type": "message", "user": "U123456789", "text": "Second reply", "ts": "1704067202.000001", "thread_ts": "1704067200.000001"}
+
+		response := map[string]interface{}{
+			"ok":                true,
+			"messages":          []map[string]interface{}{parent, first},
+			"has_more":          true,
+			"response_metadata": map[string]string{"next_cursor": "page2"},
+		}
+		if r.FormValue("cursor") == "page2" {
+			response = map[string]interface{}{
+				"ok":       true,
+				"messages": []map[string]interface{}{parent, first, second},
+				"has_more": false,
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/users.info", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok":   true,
+			"user": map[string]interface{}{"id": "U123456789", "name": "alice"},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	output, err := client.ExportChannel(context.Background(), ExportChannelInput{Channel: "C123456789"})
+	if err != nil {
+		t.Fatalf("ExportChannel failed: %v", err)
+	}
+	if output.MessageCount != 3 {
+		t.Errorf("MessageCount: got %d, want 3", output.MessageCount)
+	}
+	if len(output.ThreadFiles) != 1 {
+		t.Fatalf("ThreadFiles: got %d, want 1", len(output.ThreadFiles))
+	}
+
+	threadData, err := os.ReadFile(output.ThreadFiles[0].Path)
+	if err != nil {
+		t.Fatalf("Failed to read thread file: %v", err)
+	}
+	var texts []string
+	for _, line := range strings.Split(strings.TrimSuffix(string(threadData), "\n"), "\n") {
+		var msg MessageInfo
+		if err := json.Unmarshal([]byte(line), &msg); err != nil {
+			t.Fatalf("Failed to unmarshal line: %v", err)
+		}
+		texts = append(texts, msg.Text)
+	}
+	want := []string{"Thread parent", "First reply", "Second reply"}
+	if strings.Join(texts, "|") != strings.Join(want, "|") {
+		t.Errorf("thread messages: got %q, want %q", texts, want)
+	}
+}
+
+func TestExportChannel_ThreadPageErrorNamesThread(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	mock.addHandler("/conversations.history", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"type": "message", "user": "U123456789", "text": "Thread parent", "ts": "1704067200.000001", "reply_count": 3},
+			},
+			"has_more": false,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	var limits []string
+	mock.addHandler("/conversations.replies", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		limits = append(limits, r.FormValue("limit"))
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"type": "message", "user": "U987654321", "text": "First reply", "ts": "1704067201.000001", "thread_ts": "1704067200.000001"},
+				{"type": "message", "user": "U987654321", "text": "Second reply", "ts": "1704067202.000001", "thread_ts": "1704067200.000001"},
+			},
+			"has_more":          true,
+			"response_metadata": map[string]string{"next_cursor": "page2"},
+		}
+		if r.FormValue("cursor") == "page2" {
+			response = map[string]interface{}{"ok": false, "error": "thread_not_found"}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/users.info", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok":   true,
+			"user": map[string]interface{}{"id": "U123456789", "name": "alice"},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+	client.threadPageSize = 2
+
+	_, err := client.ExportChannel(context.Background(), ExportChannelInput{Channel: "C123456789"})
+	if err == nil {
+		t.Fatal("ExportChannel: got nil error, want page two failure")
+	}
+	if !strings.Contains(err.Error(), "thread 1704067200.000001") || !strings.Contains(err.Error(), "page 2") {
+		t.Errorf("error should name the thread and page: %v", err)
+	}
+	if !slices.Equal(limits, []string{"2", "2"}) {
+		t.Errorf("replies limits: got %q, want [2 2]", limits)
 	}
 }
 
@@ -525,6 +827,62 @@ func TestExportChannel_WithReactions(t *testing.T) {
 	}
 }
 
+func TestExportChannel_ThreadReactionsCountedOnce(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	parent := map[string]interface{}{
+		"type": "message", "user": "U123456789", "text": "Thread parent", "ts": "1704067200.000001", "reply_count": 1,
+		"reactions": []map[string]interface{}{{"name": "thumbsup", "count": 3}},
+	}
+	reply := map[string]interface{}{
+		"type": "message", "user": "U123456789", "text": "Reply", "ts": "1704067201.000001", "thread_ts": "1704067200.000001",
+		"reactions": []map[string]interface{}{{"name": "heart", "count": 2}},
+	}
+
+	mock.addHandler("/conversations.history", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok":       true,
+			"messages": []map[string]interface{}{parent},
+			"has_more": false,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/conversations.replies", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok":       true,
+			"messages": []map[string]interface{}{parent, reply},
+			"has_more": false,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/users.info", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok":   true,
+			"user": map[string]interface{}{"id": "U123456789", "name": "alice"},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	for _, splitByDay := range []bool{false, true} {
+		output, err := client.ExportChannel(context.Background(), ExportChannelInput{Channel: "C123456789", SplitByDay: splitByDay})
+		if err != nil {
+			t.Fatalf("ExportChannel(SplitByDay=%v) failed: %v", splitByDay, err)
+		}
+		if output.ReactionCount != 5 {
+			t.Errorf("ReactionCount(SplitByDay=%v): got %d, want 5", splitByDay, output.ReactionCount)
+		}
+	}
+}
+
 func TestExportChannel_TimeRange(t *testing.T) {
 	mock := newMockSlackServer()
 	defer mock.close()
@@ -721,3 +1079,1151 @@ func TestExportChannel_Pagination(t *testing.T) {
 		t.Errorf("MessageCount: got %d, want 3", output.MessageCount)
 	}
 }
+
+func TestExportChannel_CancelledRemovesPartialFiles(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mock.addHandler("/conversations.history", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"type": "message", "user": "U123456789", "text": "Second thread", "ts": "1704067300.000001", "reply_count": 1},
+				{"type": "message", "user": "U123456789", "text": "First thread", "ts": "1704067200.000001", "reply_count": 1},
+			},
+			"has_more":          false,
+			"response_metadata": map[string]string{"next_cursor": ""},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/conversations.replies", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		ts := r.FormValue("ts")
+
+		// Simulate a shutdown signal arriving while the second thread is being exported
+		hasMore := false
+		if ts == "1704067200.000001" {
+			cancel()
+			hasMore = true
+		}
+
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"type": "message", "user": "U987654321", "text": "Reply", "ts": "1704067400.000001", "thread_ts": ts},
+			},
+			"has_more":          hasMore,
+			"response_metadata": map[string]string{"next_cursor": "next"},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/users.info", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok":   true,
+			"user": map[string]interface{}{"id": "U123456789", "name": "alice"},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	_, err := client.ExportChannel(ctx, ExportChannelInput{Channel: "C123456789"})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("ExportChannel error: got %v, want context.Canceled", err)
+	}
+
+	entries, err := os.ReadDir(responsesDir)
+	if err != nil {
+		t.Fatalf("Failed to read responses dir: %v", err)
+	}
+	for _, e := range entries {
+		t.Errorf("unexpected file left behind: %s", e.Name())
+	}
+}
+
+func TestExportChannel_VerifyReplyCounts(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	mock.addHandler("/conversations.history", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"type": "message", "user": "U123456789", "text": "Accurate", "ts": "1704067300.000001", "reply_count": 1},
+				{"type": "message", "user": "U123456789", "text": "Stale count", "ts": "1704067200.000001", "reply_count": 3},
+			},
+			"has_more":          false,
+			"response_metadata": map[string]string{"next_cursor": ""},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/conversations.replies", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		ts := r.FormValue("ts")
+		messages := []map[string]interface{}{
+			{"type": "message", "user": "U123456789", "text": "parent", "ts": ts, "thread_ts": ts},
+			{"type": "message", "user": "U987654321", "text": "reply", "ts": "1704067400.000001", "thread_ts": ts},
+		}
+		if ts == "1704067200.000001" {
+			messages = append(messages, map[string]interface{}{
+				"type": "message", "user": "U987654321", "text": "reply 2", "ts": "1704067401.000001", "thread_ts": ts,
+			})
+		}
+		response := map[string]interface{}{"ok": true, "messages": messages, "has_more": false}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/users.info", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok":   true,
+			"user": map[string]interface{}{"id": "U123456789", "name": "alice"},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	tests := []struct {
+		name   string
+		verify bool
+		want   []ReplyCountDiscrepancy
+	}{
+		{"disabled", false, nil},
+		{"enabled", true, []ReplyCountDiscrepancy{
+			{Timestamp: "1704067200.000001", Reported: 3, Actual: 2},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := client.ExportChannel(context.Background(), ExportChannelInput{
+				Channel:           "C123456789",
+				VerifyReplyCounts: tt.verify,
+			})
+			if err != nil {
+				t.Fatalf("ExportChannel failed: %v", err)
+			}
+
+			if len(output.Discrepancies) != len(tt.want) {
+				t.Fatalf("Discrepancies: got %+v, want %+v", output.Discrepancies, tt.want)
+			}
+			for i, want := range tt.want {
+				if output.Discrepancies[i] != want {
+					t.Errorf("Discrepancies[%d]: got %+v, want %+v", i, output.Discrepancies[i], want)
+				}
+			}
+			if len(output.ThreadFiles) != 2 {
+				t.Errorf("ThreadFiles: got %d, want 2", len(output.ThreadFiles))
+			}
+		})
+	}
+}
+
+func TestExportChannel_VerifyReplyCountsCountsExcludedBots(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	mock.addHandler("/conversations.history", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"type": "message", "user": "U123456789", "text": "parent", "ts": "1704067200.000001", "reply_count": 2},
+			},
+			"has_more": false,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/conversations.replies", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"type": "message", "user": "U123456789", "text": "parent", "ts": "1704067200.000001", "thread_ts": "1704067200.000001"},
+				{"type": "message", "user": "U987654321", "text": "human reply", "ts": "1704067300.000001", "thread_ts": "1704067200.000001"},
+				{"type": "message", "subtype": "bot_message", "bot_id": "B123", "username": "deploybot", "text": "bot reply", "ts": "1704067400.000001", "thread_ts": "1704067200.000001"},
+			},
+			"has_more": false,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/users.info", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "user_not_found"})
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	for _, splitByDay := range []bool{false, true} {
+		t.Run(fmt.Sprintf("split_by_day=%v", splitByDay), func(t *testing.T) {
+			output, err := client.ExportChannel(context.Background(), ExportChannelInput{
+				Channel:           "C123456789",
+				ExcludeBots:       true,
+				VerifyReplyCounts: true,
+				SplitByDay:        splitByDay,
+			})
+			if err != nil {
+				t.Fatalf("ExportChannel failed: %v", err)
+			}
+			if len(output.Discrepancies) != 0 {
+				t.Errorf("Discrepancies: got %+v, want none for a skipped bot reply", output.Discrepancies)
+			}
+			if output.MessageCount != 2 {
+				t.Errorf("MessageCount: got %d, want 2 (parent + human reply)", output.MessageCount)
+			}
+		})
+	}
+}
+
+func TestExportChannel_SplitByDay(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	mock.addHandler("/conversations.history", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"type": "message", "user": "U123456789", "text": "Day two", "ts": "1704153600.000001"},
+				{"type": "message", "user": "U123456789", "text": "Day one, later", "ts": "1704070800.000001"},
+				{"type": "message", "user": "U123456789", "text": "Day one, thread", "ts": "1704067200.000001", "reply_count": 1},
+			},
+			"has_more":          false,
+			"response_metadata": map[string]string{"next_cursor": ""},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/conversations.replies", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"type": "message", "user": "U123456789", "text": "Day one, thread", "ts": "1704067200.000001", "thread_ts": "1704067200.000001"},
+				{"type": "message", "user": "U987654321", "text": "Reply on day two", "ts": "1704160000.000001", "thread_ts": "1704067200.000001"},
+			},
+			"has_more": false,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/users.info", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok":   true,
+			"user": map[string]interface{}{"id": "U123456789", "name": "alice"},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	output, err := client.ExportChannel(context.Background(), ExportChannelInput{
+		Channel:    "C123456789",
+		SplitByDay: true,
+	})
+	if err != nil {
+		t.Fatalf("ExportChannel failed: %v", err)
+	}
+
+	if output.File != nil {
+		t.Errorf("File: got %+v, want nil", output.File)
+	}
+	if len(output.ThreadFiles) != 0 {
+		t.Errorf("ThreadFiles: got %d, want 0", len(output.ThreadFiles))
+	}
+	if output.MessageCount != 4 {
+		t.Errorf("MessageCount: got %d, want 4", output.MessageCount)
+	}
+
+	want := []struct {
+		name  string
+		texts []string
+	}{
+		{"export-C123456789-2024-01-01.jsonl", []string{"Day one, thread", "Reply on day two", "Day one, later"}},
+		{"export-C123456789-2024-01-02.jsonl", []string{"Day two"}},
+	}
+	if len(output.Files) != len(want) {
+		t.Fatalf("len(Files): got %d, want %d", len(output.Files), len(want))
+	}
+	for i, w := range want {
+		ref := output.Files[i]
+		if ref.Name != w.name {
+			t.Errorf("Files[%d].Name: got %q, want %q", i, ref.Name, w.name)
+		}
+		if ref.Lines != len(w.texts) {
+			t.Errorf("Files[%d].Lines: got %d, want %d", i, ref.Lines, len(w.texts))
+		}
+
+		content, err := os.ReadFile(ref.Path)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", ref.Path, err)
+		}
+		lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+		if len(lines) != len(w.texts) {
+			t.Fatalf("%s: got %d lines, want %d", ref.Name, len(lines), len(w.texts))
+		}
+		for j, line := range lines {
+			var msg MessageInfo
+			if err := json.Unmarshal([]byte(line), &msg); err != nil {
+				t.Fatalf("failed to parse line %d of %s: %v", j, ref.Name, err)
+			}
+			if msg.Text != w.texts[j] {
+				t.Errorf("%s line %d: got %q, want %q", ref.Name, j, msg.Text, w.texts[j])
+			}
+		}
+	}
+}
+
+func TestExportChannel_ResumeFromCheckpoint(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	var cursors []string
+	failPage2 := true
+	mock.addHandler("/conversations.history", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		cursor := r.FormValue("cursor")
+		cursors = append(cursors, cursor)
+
+		var response map[string]interface{}
+		switch {
+		case cursor == "":
+			response = map[string]interface{}{
+				"ok": true,
+				"messages": []map[string]interface{}{
+					{"type": "message", "user": "U123456789", "text": "Third", "ts": "1704067300.000001"},
+					{"type": "message", "user": "U123456789", "text": "Second", "ts": "1704067200.000001", "reply_count": 1},
+				},
+				"has_more":          true,
+				"response_metadata": map[string]string{"next_cursor": "page2"},
+			}
+		case failPage2:
+			failPage2 = false
+			response = map[string]interface{}{"ok": false, "error": "fatal_error"}
+		default:
+			response = map[string]interface{}{
+				"ok": true,
+				"messages": []map[string]interface{}{
+					{"type": "message", "user": "U987654321", "text": "First", "ts": "1704067100.000001"},
+				},
+				"has_more":          false,
+				"response_metadata": map[string]string{"next_cursor": ""},
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/conversations.replies", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"type": "message", "user": "U123456789", "text": "Second", "ts": "1704067200.000001", "thread_ts": "1704067200.000001"},
+				{"type": "message", "user": "U987654321", "text": "Reply", "ts": "1704067250.000001", "thread_ts": "1704067200.000001"},
+			},
+			"has_more": false,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/users.info", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok":   true,
+			"user": map[string]interface{}{"id": "U123456789", "name": "alice"},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+	client.checkpointDir = t.TempDir()
+
+	input := ExportChannelInput{Channel: "C123456789", Resume: true}
+	if _, err := client.ExportChannel(context.Background(), input); err == nil {
+		t.Fatal("expected first export to fail on page 2")
+	}
+
+	checkpoints, _ := os.ReadDir(client.checkpointDir)
+	if len(checkpoints) != 1 {
+		t.Fatalf("checkpoint files after failure: got %d, want 1", len(checkpoints))
+	}
+
+	output, err := client.ExportChannel(context.Background(), input)
+	if err != nil {
+		t.Fatalf("resumed ExportChannel failed: %v", err)
+	}
+
+	wantCursors := []string{"", "page2", "page2"}
+	if strings.Join(cursors, ",") != strings.Join(wantCursors, ",") {
+		t.Errorf("history cursors: got %q, want %q", cursors, wantCursors)
+	}
+
+	if output.MessageCount != 4 {
+		t.Errorf("MessageCount: got %d, want 4 (3 top-level + 1 reply)", output.MessageCount)
+	}
+	if output.ThreadCount != 1 || len(output.ThreadFiles) != 1 {
+		t.Errorf("threads: got count=%d files=%d, want 1 and 1", output.ThreadCount, len(output.ThreadFiles))
+	}
+	if output.UniqueUsers != 2 {
+		t.Errorf("UniqueUsers: got %d, want 2", output.UniqueUsers)
+	}
+
+	content, err := os.ReadFile(output.File.Path)
+	if err != nil {
+		t.Fatalf("failed to read export: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	wantTexts := []string{"First", "Second", "Third"}
+	if len(lines) != len(wantTexts) {
+		t.Fatalf("export lines: got %d, want %d", len(lines), len(wantTexts))
+	}
+	for i, line := range lines {
+		var msg MessageInfo
+		if err := json.Unmarshal([]byte(line), &msg); err != nil {
+			t.Fatalf("failed to parse line %d: %v", i, err)
+		}
+		if msg.Text != wantTexts[i] {
+			t.Errorf("line %d: got %q, want %q", i, msg.Text, wantTexts[i])
+		}
+	}
+
+	checkpoints, _ = os.ReadDir(client.checkpointDir)
+	if len(checkpoints) != 0 {
+		t.Errorf("checkpoint files after success: got %d, want 0", len(checkpoints))
+	}
+	if tmps, _ := filepath.Glob(filepath.Join(responsesDir, "export-tmp-*")); len(tmps) != 0 {
+		t.Errorf("leftover temp files: %v", tmps)
+	}
+}
+
+func TestExportChannel_ResumeAfterThreadFailure(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	var historyCalls int
+	mock.addHandler("/conversations.history", func(w http.ResponseWriter, r *http.Request) {
+		historyCalls++
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"type": "message", "user": "U123456789", "text": "Second", "ts": "1704067200.000001", "reply_count": 1},
+				{"type": "message", "user": "U123456789", "text": "First", "ts": "1704067100.000001", "reply_count": 1},
+			},
+			"has_more": false,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	failSecond := true
+	mock.addHandler("/conversations.replies", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		ts := r.FormValue("ts")
+		var response map[string]interface{}
+		if ts == "1704067100.000001" && failSecond {
+			failSecond = false
+			response = map[string]interface{}{"ok": false, "error": "fatal_error"}
+		} else {
+			response = map[string]interface{}{
+				"ok": true,
+				"messages": []map[string]interface{}{
+					{"type": "message", "user": "U123456789", "text": "Parent", "ts": ts, "thread_ts": ts},
+					{"type": "message", "user": "U987654321", "text": "Reply", "ts": "1704067250.000001", "thread_ts": ts},
+				},
+				"has_more": false,
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/users.info", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok":   true,
+			"user": map[string]interface{}{"id": "U123456789", "name": "alice"},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+	client.checkpointDir = t.TempDir()
+
+	input := ExportChannelInput{Channel: "C123456789", Resume: true}
+	if _, err := client.ExportChannel(context.Background(), input); err == nil {
+		t.Fatal("expected first export to fail in the thread pass")
+	}
+
+	checkpoints, _ := os.ReadDir(client.checkpointDir)
+	if len(checkpoints) != 1 {
+		t.Fatalf("checkpoint files after failure: got %d, want 1", len(checkpoints))
+	}
+
+	output, err := client.ExportChannel(context.Background(), input)
+	if err != nil {
+		t.Fatalf("resumed ExportChannel failed: %v", err)
+	}
+
+	if historyCalls != 1 {
+		t.Errorf("conversations.history calls: got %d, want 1", historyCalls)
+	}
+	if output.MessageCount != 4 {
+		t.Errorf("MessageCount: got %d, want 4 (2 top-level + 2 replies)", output.MessageCount)
+	}
+	if output.ThreadCount != 2 || len(output.ThreadFiles) != 2 {
+		t.Errorf("threads: got count=%d files=%d, want 2 and 2", output.ThreadCount, len(output.ThreadFiles))
+	}
+	if output.File.Lines != 2 {
+		t.Errorf("export lines: got %d, want 2", output.File.Lines)
+	}
+
+	checkpoints, _ = os.ReadDir(client.checkpointDir)
+	if len(checkpoints) != 0 {
+		t.Errorf("checkpoint files after success: got %d, want 0", len(checkpoints))
+	}
+	for _, pattern := range []string{"export-tmp-*", "export-threads-*"} {
+		if tmps, _ := filepath.Glob(filepath.Join(responsesDir, pattern)); len(tmps) != 0 {
+			t.Errorf("leftover temp files: %v", tmps)
+		}
+	}
+}
+
+func TestExportChannel_ResumeRequiresSameOptions(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	mock.addHandler("/conversations.history", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		var response map[string]interface{}
+		if r.FormValue("cursor") == "" {
+			response = map[string]interface{}{
+				"ok": true,
+				"messages": []map[string]interface{}{
+					{"type": "message", "user": "U123456789", "text": "Second", "ts": "1704067200.000001"},
+				},
+				"has_more":          true,
+				"response_metadata": map[string]string{"next_cursor": "page2"},
+			}
+		} else {
+			response = map[string]interface{}{"ok": false, "error": "fatal_error"}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+	mock.addHandler("/users.info", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "user_not_found"})
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+	client.checkpointDir = t.TempDir()
+
+	input := ExportChannelInput{Channel: "C123456789", Resume: true}
+	if _, err := client.ExportChannel(context.Background(), input); err == nil {
+		t.Fatal("expected first export to fail on page 2")
+	}
+	firstTmps, _ := filepath.Glob(filepath.Join(responsesDir, "export-tmp-*"))
+	if len(firstTmps) != 1 {
+		t.Fatalf("temp files after first run: got %v, want one", firstTmps)
+	}
+
+	changed := input
+	changed.IncludeBlocks = true
+	_, err := client.ExportChannel(context.Background(), changed)
+	if err == nil || !strings.Contains(err.Error(), "different export options") {
+		t.Errorf("resume with different options: got %v, want options mismatch error", err)
+	}
+
+	// Starting over replaces the checkpoint and must remove the file it referenced
+	fresh := input
+	fresh.Resume = false
+	if _, err := client.ExportChannel(context.Background(), fresh); err == nil {
+		t.Fatal("expected fresh export to fail on page 2")
+	}
+	if _, err := os.Stat(firstTmps[0]); !os.IsNotExist(err) {
+		t.Errorf("old checkpoint temp file %s still exists (stat err %v)", firstTmps[0], err)
+	}
+	if tmps, _ := filepath.Glob(filepath.Join(responsesDir, "export-tmp-*")); len(tmps) != 1 {
+		t.Errorf("temp files after fresh run: got %v, want one", tmps)
+	}
+}
+
+func TestExportChannel_ReportsProgress(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	mock.addHandler("/conversations.history", func(w http.ResponseWriter, r *http.Request) {
+		messages := make([]map[string]interface{}, 0, 2500)
+		for i := range 2500 {
+			messages = append(messages, map[string]interface{}{
+				"type": "message",
+				"text": "msg",
+				"ts":   fmt.Sprintf("%d.000001", 1704070000-i),
+			})
+		}
+		response := map[string]interface{}{"ok": true, "messages": messages, "has_more": false}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	var updates []string
+	ctx := WithProgress(context.Background(), func(message string, progress float64) {
+		updates = append(updates, fmt.Sprintf("%s (%.0f)", message, progress))
+	})
+
+	if _, err := client.ExportChannel(ctx, ExportChannelInput{Channel: "C123456789"}); err != nil {
+		t.Fatalf("ExportChannel failed: %v", err)
+	}
+
+	want := []string{
+		"fetched 1000 messages, 0 threads (1000)",
+		"fetched 2000 messages, 0 threads (2000)",
+	}
+	if strings.Join(updates, "|") != strings.Join(want, "|") {
+		t.Errorf("progress updates: got %q, want %q", updates, want)
+	}
+}
+
+func TestExportChannel_OutputPath(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	mock.addHandler("/conversations.history", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"type": "message", "user": "U123456789", "text": "Hi there", "ts": "1704067201.000001"},
+				{"type": "message", "user": "U123456789", "text": "Hello world", "ts": "1704067200.000001"},
+			},
+			"has_more":          false,
+			"response_metadata": map[string]string{"next_cursor": ""},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/users.info", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok":   true,
+			"user": map[string]interface{}{"id": "U123456789", "name": "alice"},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	input := ExportChannelInput{
+		Channel:    "C123456789",
+		OutputPath: filepath.Join(t.TempDir(), "general.jsonl"),
+	}
+	if _, err := client.ExportChannel(context.Background(), input); err == nil {
+		t.Fatal("expected output_path to be rejected without an export directory")
+	}
+
+	client.exportDir = filepath.Dir(input.OutputPath)
+	outputPath, err := filepath.EvalSymlinks(client.exportDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	outputPath = filepath.Join(outputPath, "general.jsonl")
+	output, err := client.ExportChannel(context.Background(), input)
+	if err != nil {
+		t.Fatalf("ExportChannel failed: %v", err)
+	}
+
+	if output.File.Path != outputPath {
+		t.Errorf("File.Path: got %q, want %q", output.File.Path, outputPath)
+	}
+	if output.File.URI != "" {
+		t.Errorf("File.URI: got %q, want empty for files outside the responses directory", output.File.URI)
+	}
+	if output.File.Lines != 2 {
+		t.Errorf("File.Lines: got %d, want 2", output.File.Lines)
+	}
+
+	content, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatalf("failed to read export: %v", err)
+	}
+	if !strings.HasPrefix(string(content), `{"timestamp"`) || strings.Count(string(content), "\n") != 2 {
+		t.Errorf("unexpected export content: %q", content)
+	}
+
+	if leftovers, _ := filepath.Glob(filepath.Join(responsesDir, "export-*")); len(leftovers) != 0 {
+		t.Errorf("files left in responses directory: %v", leftovers)
+	}
+
+	if _, err := client.ExportChannel(context.Background(), input); err == nil {
+		t.Error("expected an existing output_path to be refused without overwrite")
+	}
+	input.Overwrite = true
+	if _, err := client.ExportChannel(context.Background(), input); err != nil {
+		t.Errorf("ExportChannel with overwrite failed: %v", err)
+	}
+}
+
+func TestValidateOutputPath(t *testing.T) {
+	root := t.TempDir()
+	outside := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "existing.jsonl"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, filepath.Join(root, "escape")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(outside, "target"), filepath.Join(root, "link.jsonl")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		root      string
+		path      string
+		overwrite bool
+		wantErr   bool
+	}{
+		{"absolute file", root, filepath.Join(root, "out.jsonl"), false, false},
+		{"subdirectory", root, filepath.Join(root, "sub", "out.jsonl"), false, false},
+		{"no export directory", "", filepath.Join(root, "out.jsonl"), false, true},
+		{"relative", root, "out.jsonl", false, true},
+		{"traversal", root, root + "/../out.jsonl", false, true},
+		{"outside root", root, filepath.Join(outside, "out.jsonl"), false, true},
+		{"symlinked directory outside root", root, filepath.Join(root, "escape", "out.jsonl"), false, true},
+		{"symlinked file", root, filepath.Join(root, "link.jsonl"), true, true},
+		{"existing file", root, filepath.Join(root, "existing.jsonl"), false, true},
+		{"existing file with overwrite", root, filepath.Join(root, "existing.jsonl"), true, false},
+		{"directory", root, filepath.Join(root, "sub"), true, true},
+		{"missing directory", root, filepath.Join(root, "missing", "out.jsonl"), false, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := validateOutputPath(tt.root, tt.path, tt.overwrite)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateOutputPath(%q, %q): got err=%v, wantErr %v", tt.root, tt.path, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExportChannel_IncludePermalinks(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	mock.addHandler("/conversations.history", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"type": "message", "user": "U123456789", "text": "Thread", "ts": "1704067200.000001", "reply_count": 1},
+			},
+			"has_more":          false,
+			"response_metadata": map[string]string{"next_cursor": ""},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/conversations.replies", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"type": "message", "user": "U123456789", "text": "Thread", "ts": "1704067200.000001", "thread_ts": "1704067200.000001"},
+				{"type": "message", "user": "U123456789", "text": "Reply", "ts": "1704067300.000001", "thread_ts": "1704067200.000001"},
+			},
+			"has_more": false,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	var permalinkCalls int
+	mock.addHandler("/chat.getPermalink", func(w http.ResponseWriter, r *http.Request) {
+		permalinkCalls++
+		r.ParseForm()
+		response := map[string]interface{}{
+			"ok":        true,
+			"channel":   r.FormValue("channel"),
+			"permalink": "https://example.slack.com/archives/" + r.FormValue("channel") + "/p" + r.FormValue("message_ts"),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/users.info", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok":   true,
+			"user": map[string]interface{}{"id": "U123456789", "name": "alice"},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	readMessages := func(path string) []MessageInfo {
+		t.Helper()
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", path, err)
+		}
+		var messages []MessageInfo
+		for _, line := range strings.Split(strings.TrimSpace(string(content)), "\n") {
+			var msg MessageInfo
+			if err := json.Unmarshal([]byte(line), &msg); err != nil {
+				t.Fatalf("failed to parse line: %v", err)
+			}
+			messages = append(messages, msg)
+		}
+		return messages
+	}
+
+	output, err := client.ExportChannel(context.Background(), ExportChannelInput{Channel: "C123456789"})
+	if err != nil {
+		t.Fatalf("ExportChannel failed: %v", err)
+	}
+	if permalinkCalls != 0 {
+		t.Errorf("chat.getPermalink calls without include_permalinks: got %d, want 0", permalinkCalls)
+	}
+	if got := readMessages(output.File.Path)[0].Permalink; got != "" {
+		t.Errorf("Permalink without include_permalinks: got %q, want empty", got)
+	}
+
+	output, err = client.ExportChannel(context.Background(), ExportChannelInput{Channel: "C123456789", IncludePermalinks: true})
+	if err != nil {
+		t.Fatalf("ExportChannel failed: %v", err)
+	}
+
+	want := "https://example.slack.com/archives/C123456789/p1704067200.000001"
+	if got := readMessages(output.File.Path)[0].Permalink; got != want {
+		t.Errorf("main file Permalink: got %q, want %q", got, want)
+	}
+	thread := readMessages(output.ThreadFiles[0].Path)
+	if len(thread) != 2 || thread[1].Permalink != "https://example.slack.com/archives/C123456789/p1704067300.000001" {
+		t.Errorf("thread file permalinks: got %+v", thread)
+	}
+}
+
+func TestExportChannel_IncludeBlocks(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	mock.addHandler("/conversations.history", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{
+					"type": "message", "user": "U123456789", "text": "", "ts": "1704067200.000001",
+					"attachments": []map[string]interface{}{
+						{"title": "Build failed", "title_link": "https://ci.example.com/42", "color": "danger"},
+					},
+				},
+			},
+			"has_more": false,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/users.info", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok":   true,
+			"user": map[string]interface{}{"id": "U123456789", "name": "alice"},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	readLine := func(path string) MessageInfo {
+		t.Helper()
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", path, err)
+		}
+		var msg MessageInfo
+		if err := json.Unmarshal(bytes.TrimSpace(content), &msg); err != nil {
+			t.Fatalf("failed to parse line: %v", err)
+		}
+		return msg
+	}
+
+	output, err := client.ExportChannel(context.Background(), ExportChannelInput{Channel: "C123456789"})
+	if err != nil {
+		t.Fatalf("ExportChannel failed: %v", err)
+	}
+	if got := readLine(output.File.Path); got.Attachments != nil || got.Blocks != nil {
+		t.Errorf("raw content without include_blocks: got attachments %s, blocks %s", got.Attachments, got.Blocks)
+	}
+
+	output, err = client.ExportChannel(context.Background(), ExportChannelInput{Channel: "C123456789", IncludeBlocks: true})
+	if err != nil {
+		t.Fatalf("ExportChannel failed: %v", err)
+	}
+	got := readLine(output.File.Path)
+	if got.Blocks != nil {
+		t.Errorf("Blocks: got %s, want none", got.Blocks)
+	}
+	var attachments []slack.Attachment
+	if err := json.Unmarshal(got.Attachments, &attachments); err != nil {
+		t.Fatalf("Attachments %s: %v", got.Attachments, err)
+	}
+	if len(attachments) != 1 || attachments[0].Title != "Build failed" || attachments[0].TitleLink != "https://ci.example.com/42" {
+		t.Errorf("Attachments: got %+v", attachments)
+	}
+}
+
+func TestReverseCopyLines_OversizedLine(t *testing.T) {
+	dir := t.TempDir()
+	src, err := os.Create(filepath.Join(dir, "src.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer src.Close()
+
+	// Larger than the 10MB cap the line scanner used to impose
+	huge := strings.Repeat("x", 11*1024*1024)
+	lines := []string{"first", huge, "last"}
+	var offsets []int64
+	var pos int64
+	for _, line := range lines {
+		offsets = append(offsets, pos)
+		n, err := src.WriteString(line + "\n")
+		if err != nil {
+			t.Fatal(err)
+		}
+		pos += int64(n)
+	}
+
+	dst, err := os.Create(filepath.Join(dir, "dst.jsonl"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer dst.Close()
+
+	if err := reverseCopyLines(context.Background(), src, dst, offsets); err != nil {
+		t.Fatalf("reverseCopyLines failed: %v", err)
+	}
+
+	data, err := os.ReadFile(dst.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "last\n" + huge + "\nfirst\n"; string(data) != want {
+		t.Errorf("output: got %d bytes, want %d bytes in reverse order", len(data), len(want))
+	}
+}
+
+func BenchmarkReverseCopyLines(b *testing.B) {
+	dir := b.TempDir()
+	src, err := os.Create(filepath.Join(dir, "src.jsonl"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer src.Close()
+
+	const lines = 100_000
+	offsets := make([]int64, 0, lines)
+	var pos int64
+	for i := range lines {
+		offsets = append(offsets, pos)
+		n, err := fmt.Fprintf(src, `{"raw_timestamp":"%d.000100","text":"message %d"}`+"\n", 1700000000+i, i)
+		if err != nil {
+			b.Fatal(err)
+		}
+		pos += int64(n)
+	}
+
+	b.ResetTimer()
+	for range b.N {
+		dst, err := os.Create(filepath.Join(dir, "dst.jsonl"))
+		if err != nil {
+			b.Fatal(err)
+		}
+		if err := reverseCopyLines(context.Background(), src, dst, offsets); err != nil {
+			b.Fatal(err)
+		}
+		dst.Close()
+	}
+}
+
+func TestExportChannel_BotMessages(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	mock.addHandler("/conversations.history", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"type": "message", "subtype": "bot_message", "bot_id": "B000000001", "text": "Deploy finished", "ts": "1704067300.000001"},
+				{"type": "message", "user": "U123456789", "text": "Deploying now", "ts": "1704067200.000001"},
+			},
+			"has_more": false,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/users.info", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok":   true,
+			"user": map[string]interface{}{"id": "U123456789", "name": "alice"},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	botCalls := 0
+	mock.addHandler("/bots.info", func(w http.ResponseWriter, r *http.Request) {
+		botCalls++
+		response := map[string]interface{}{
+			"ok":  true,
+			"bot": map[string]interface{}{"id": "B000000001", "name": "deploybot"},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	readNames := func(path string) []string {
+		t.Helper()
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read file: %v", err)
+		}
+		var names []string
+		for _, line := range strings.Split(strings.TrimSuffix(string(data), "\n"), "\n") {
+			var msg MessageInfo
+			if err := json.Unmarshal([]byte(line), &msg); err != nil {
+				t.Fatalf("Failed to unmarshal line: %v", err)
+			}
+			names = append(names, msg.UserName)
+		}
+		return names
+	}
+
+	output, err := client.ExportChannel(context.Background(), ExportChannelInput{Channel: "C123456789"})
+	if err != nil {
+		t.Fatalf("ExportChannel failed: %v", err)
+	}
+	if got := readNames(output.File.Path); !slices.Equal(got, []string{"alice", "deploybot"}) {
+		t.Errorf("UserNames: got %v, want [alice deploybot]", got)
+	}
+	if botCalls != 1 {
+		t.Errorf("bots.info calls: got %d, want 1", botCalls)
+	}
+
+	output, err = client.ExportChannel(context.Background(), ExportChannelInput{Channel: "C123456789", ExcludeBots: true})
+	if err != nil {
+		t.Fatalf("ExportChannel failed: %v", err)
+	}
+	if output.MessageCount != 1 {
+		t.Errorf("MessageCount with exclude_bots: got %d, want 1", output.MessageCount)
+	}
+	if got := readNames(output.File.Path); !slices.Equal(got, []string{"alice"}) {
+		t.Errorf("UserNames with exclude_bots: got %v, want [alice]", got)
+	}
+}
+
+func TestExportChannel_Leaderboards(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	mock.addHandler("/conversations.history", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"type": "message", "user": "U222", "text": "Me too", "ts": "1704067400.000001",
+					"reactions": []map[string]interface{}{{"name": "tada", "count": 1}}},
+				{"type": "message", "user": "U111", "text": "Shipped", "ts": "1704067300.000001",
+					"reactions": []map[string]interface{}{{"name": "tada", "count": 4}, {"name": "rocket", "count": 2}}},
+				{"type": "message", "user": "U111", "text": "Shipping today", "ts": "1704067200.000001",
+					"reply_count": 1, "thread_ts": "1704067200.000001"},
+			},
+			"has_more": false,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/conversations.replies", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"type": "message", "user": "U111", "text": "Shipping today", "ts": "1704067200.000001", "thread_ts": "1704067200.000001"},
+				{"type": "message", "user": "U333", "text": "Nice", "ts": "1704067250.000001", "thread_ts": "1704067200.000001",
+					"reactions": []map[string]interface{}{{"name": "eyes", "count": 1}}},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	names := map[string]string{"U111": "alice", "U222": "bob", "U333": "carol"}
+	mock.addHandler("/users.info", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		id := r.FormValue("user")
+		response := map[string]interface{}{
+			"ok":   true,
+			"user": map[string]interface{}{"id": id, "name": names[id]},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	output, err := client.ExportChannel(context.Background(), ExportChannelInput{Channel: "C123456789"})
+	if err != nil {
+		t.Fatalf("ExportChannel failed: %v", err)
+	}
+
+	wantReactions := []ReactionInfo{{Name: "tada", Count: 5}, {Name: "rocket", Count: 2}, {Name: "eyes", Count: 1}}
+	if !slices.Equal(output.TopReactions, wantReactions) {
+		t.Errorf("TopReactions: got %v, want %v", output.TopReactions, wantReactions)
+	}
+	wantUsers := []UserActivity{
+		{User: "U111", Name: "alice", Count: 2},
+		{User: "U222", Name: "bob", Count: 1},
+		{User: "U333", Name: "carol", Count: 1},
+	}
+	if !slices.Equal(output.TopUsers, wantUsers) {
+		t.Errorf("TopUsers: got %v, want %v", output.TopUsers, wantUsers)
+	}
+}
@@ -0,0 +1,62 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/slack-go/slack"
+)
+
+// GetMessageInput defines input for fetching a single message
+type GetMessageInput struct {
+	Channel   string `json:"channel" jsonschema:"Channel ID, name or message permalink (e.g., C1234567890, #general or https://example.slack.com/archives/C1234567890/p1234567890123456)"`
+	Timestamp string `json:"timestamp,omitempty" jsonschema:"Message timestamp (e.g., 1234567890.123456). Optional when channel is a permalink"`
+
+	WorkspaceSelector
+}
+
+// GetMessageOutput contains the requested message
+type GetMessageOutput struct {
+	ChannelID string      `json:"channel_id"`
+	Message   MessageInfo `json:"message"`
+}
+
+// GetMessage fetches one top-level message by its timestamp, including
+// reactions, files, and any shared message it quotes. Thread replies are not
+// returned by conversations.history; use ReadThread for those.
+func (c *Service) GetMessage(ctx context.Context, input GetMessageInput) (GetMessageOutput, error) {
+	if input.Timestamp == "" {
+		input.Timestamp, _ = permalinkTimestamps(input.Channel)
+	}
+	if input.Timestamp == "" {
+		return GetMessageOutput{}, fmt.Errorf("timestamp is required")
+	}
+
+	channelID, err := c.GetChannelID(ctx, input.Channel, messageChannelTypes...)
+	if err != nil {
+		return GetMessageOutput{}, err
+	}
+
+	history, err := c.api.GetConversationHistoryContext(ctx, &slack.GetConversationHistoryParameters{
+		ChannelID: channelID,
+		Latest:    input.Timestamp,
+		Oldest:    input.Timestamp,
+		Inclusive: true,
+		Limit:     1,
+	})
+	if err != nil {
+		return GetMessageOutput{}, fmt.Errorf("failed to get message: %w", err)
+	}
+
+	for _, msg := range history.Messages {
+		if msg.Timestamp != input.Timestamp {
+			continue
+		}
+		names := c.newUserNameCache(ctx)
+		info := buildMessageInfo(msg, msg.ThreadTimestamp, names.Get(msg.User), c.displayLocation())
+		info.Shared = extractSharedMessage(msg)
+		return GetMessageOutput{ChannelID: channelID, Message: info}, nil
+	}
+
+	return GetMessageOutput{}, fmt.Errorf("message %s not found in channel %s (thread replies can be read with slack_read_thread)", input.Timestamp, channelID)
+}
@@ -0,0 +1,125 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+)
+
+func TestGetMessage(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	var gotParams map[string]string
+	mock.addHandler("/conversations.history", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		gotParams = map[string]string{
+			"latest":    r.FormValue("latest"),
+			"oldest":    r.FormValue("oldest"),
+			"inclusive": r.FormValue("inclusive"),
+			"limit":     r.FormValue("limit"),
+		}
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{
+					"type":        "message",
+					"user":        "U111",
+					"text":        "Release is out",
+					"ts":          "1700000000.000100",
+					"reply_count": 2,
+					"reactions":   []map[string]interface{}{{"name": "tada", "count": 3}},
+				},
+			},
+			"has_more": false,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/users.info", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok":   true,
+			"user": map[string]interface{}{"id": "U111", "name": "alice"},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	output, err := client.GetMessage(context.Background(), GetMessageInput{
+		Channel:   "C123456789",
+		Timestamp: "1700000000.000100",
+	})
+	if err != nil {
+		t.Fatalf("GetMessage failed: %v", err)
+	}
+
+	wantParams := map[string]string{
+		"latest":    "1700000000.000100",
+		"oldest":    "1700000000.000100",
+		"inclusive": "1",
+		"limit":     "1",
+	}
+	for k, want := range wantParams {
+		if gotParams[k] != want {
+			t.Errorf("request %s: got %q, want %q", k, gotParams[k], want)
+		}
+	}
+
+	msg := output.Message
+	if msg.Text != "Release is out" || msg.UserName != "alice" || msg.ReplyCount != 2 {
+		t.Errorf("Message: got %+v", msg)
+	}
+	if len(msg.Reactions) != 1 || msg.Reactions[0].Name != "tada" || msg.Reactions[0].Count != 3 {
+		t.Errorf("Reactions: got %+v, want [tada x3]", msg.Reactions)
+	}
+
+	if _, err := client.GetMessage(context.Background(), GetMessageInput{Channel: "C123456789", Timestamp: "1700000001.000000"}); err == nil {
+		t.Error("expected error when no message matches the timestamp")
+	}
+}
+
+func TestGetMessage_Permalink(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	var gotChannel, gotLatest string
+	mock.addHandler("/conversations.history", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		gotChannel = r.FormValue("channel")
+		gotLatest = r.FormValue("latest")
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"type": "message", "text": "Release is out", "ts": "1700000000.000100"},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	output, err := client.GetMessage(context.Background(), GetMessageInput{
+		Channel: "https://example.slack.com/archives/C123456789/p1700000000000100",
+	})
+	if err != nil {
+		t.Fatalf("GetMessage failed: %v", err)
+	}
+
+	if gotChannel != "C123456789" || gotLatest != "1700000000.000100" {
+		t.Errorf("request: got channel %q latest %q, want C123456789 1700000000.000100", gotChannel, gotLatest)
+	}
+	if output.ChannelID != "C123456789" {
+		t.Errorf("ChannelID: got %q, want C123456789", output.ChannelID)
+	}
+	if output.Message.Text != "Release is out" {
+		t.Errorf("Text: got %q, want %q", output.Message.Text, "Release is out")
+	}
+}
@@ -3,6 +3,7 @@ package slack
 import (
 	"context"
 	"fmt"
+	"net/url"
 
 	"github.com/slack-go/slack"
 )
@@ -11,6 +12,10 @@ import (
 type GetPermalinkInput struct {
 	Channel   string `json:"channel" jsonschema:"Channel ID (e.g., C1234567890)"`
 	Timestamp string `json:"timestamp" jsonschema:"Message timestamp (e.g., 1234567890.123456)"`
+
+	ThreadTimestamp string `json:"thread_ts,omitempty" jsonschema:"Parent message timestamp, when the message is a thread reply. Makes the link open the reply inside its thread"`
+
+	WorkspaceSelector
 }
 
 // GetPermalinkOutput contains the permalink
@@ -22,7 +27,7 @@ type GetPermalinkOutput struct {
 
 // GetPermalink gets a permalink to a specific message
 func (c *Service) GetPermalink(ctx context.Context, input GetPermalinkInput) (GetPermalinkOutput, error) {
-	channelID, err := c.GetChannelID(input.Channel)
+	channelID, err := c.GetChannelID(ctx, input.Channel)
 	if err != nil {
 		return GetPermalinkOutput{}, err
 	}
@@ -34,6 +39,12 @@ func (c *Service) GetPermalink(ctx context.Context, input GetPermalinkInput) (Ge
 	if err != nil {
 		return GetPermalinkOutput{}, fmt.Errorf("failed to get permalink: %w", err)
 	}
+	if input.ThreadTimestamp != "" {
+		permalink, err = withThreadTimestamp(permalink, input.ThreadTimestamp, channelID)
+		if err != nil {
+			return GetPermalinkOutput{}, err
+		}
+	}
 
 	return GetPermalinkOutput{
 		Permalink: permalink,
@@ -41,3 +52,20 @@ func (c *Service) GetPermalink(ctx context.Context, input GetPermalinkInput) (Ge
 		Timestamp: input.Timestamp,
 	}, nil
 }
+
+// withThreadTimestamp adds the thread_ts and cid query parameters Slack uses
+// to open a reply inside its thread, unless the permalink already has them
+func withThreadTimestamp(permalink, threadTS, channelID string) (string, error) {
+	u, err := url.Parse(permalink)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse permalink %q: %w", permalink, err)
+	}
+	q := u.Query()
+	if q.Get("thread_ts") != "" {
+		return permalink, nil
+	}
+	q.Set("thread_ts", threadTS)
+	q.Set("cid", channelID)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"net/http"
+	"net/url"
 	"os"
 	"testing"
 )
@@ -59,3 +60,40 @@ func TestGetPermalink(t *testing.T) {
 		t.Errorf("Channel: got %q, want %q", output.Channel, "C123456789")
 	}
 }
+
+func TestGetPermalink_ThreadTimestamp(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	mock.addHandler("/chat.getPermalink", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok":        true,
+			"permalink": "https://example.slack.com/archives/C123456789/p1234567899000000",
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	output, err := client.GetPermalink(context.Background(), GetPermalinkInput{
+		Channel:         "C123456789",
+		Timestamp:       "1234567899.000000",
+		ThreadTimestamp: "1234567890.123456",
+	})
+	if err != nil {
+		t.Fatalf("GetPermalink failed: %v", err)
+	}
+
+	u, err := url.Parse(output.Permalink)
+	if err != nil {
+		t.Fatalf("invalid permalink %q: %v", output.Permalink, err)
+	}
+	if got := u.Query().Get("thread_ts"); got != "1234567890.123456" {
+		t.Errorf("thread_ts: got %q, want %q (permalink %q)", got, "1234567890.123456", output.Permalink)
+	}
+	if got := u.Query().Get("cid"); got != "C123456789" {
+		t.Errorf("cid: got %q, want %q", got, "C123456789")
+	}
+}
@@ -11,6 +11,8 @@ import (
 type GetUserInput struct {
 	User  string `json:"user,omitempty" jsonschema:"User ID (e.g., U1234567890)"`
 	Email string `json:"email,omitempty" jsonschema:"User email address"`
+
+	WorkspaceSelector
 }
 
 // UserInfo represents a Slack user
@@ -0,0 +1,120 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// defaultInactiveWindow is how far back InactiveMembers looks when Since is omitted
+const defaultInactiveWindow = 30 * 24 * time.Hour
+
+// InactiveMembersInput defines input for finding members who haven't posted recently
+type InactiveMembersInput struct {
+	Channel string `json:"channel" jsonschema:"Channel ID or name (e.g., C1234567890 or #general)"`
+	Since   string `json:"since,omitempty" jsonschema:"Only count messages after this Unix timestamp (default: 30 days ago)"`
+
+	WorkspaceSelector
+}
+
+// InactiveMember identifies a channel member with no recent messages
+type InactiveMember struct {
+	UserID   string `json:"user_id"`
+	UserName string `json:"user_name,omitempty"`
+}
+
+// InactiveMembersOutput contains the members who have not posted since the bound
+type InactiveMembersOutput struct {
+	ChannelID     string           `json:"channel_id"`
+	Since         Timestamp        `json:"since"`
+	Members       []InactiveMember `json:"members"`
+	TotalMembers  int              `json:"total_members"`
+	ActiveCount   int              `json:"active_count"`
+	InactiveCount int              `json:"inactive_count"`
+}
+
+// InactiveMembers lists channel members who have not posted a top-level message
+// (or a reply broadcast to the channel) since the given time.
+func (c *Service) InactiveMembers(ctx context.Context, input InactiveMembersInput) (InactiveMembersOutput, error) {
+	channelID, err := c.GetChannelID(ctx, input.Channel)
+	if err != nil {
+		return InactiveMembersOutput{}, err
+	}
+
+	since := input.Since
+	if since == "" {
+		since = strconv.FormatInt(time.Now().Add(-defaultInactiveWindow).Unix(), 10)
+	}
+
+	members, err := c.conversationMembers(ctx, channelID)
+	if err != nil {
+		return InactiveMembersOutput{}, err
+	}
+
+	posters, err := c.recentPosters(ctx, channelID, since)
+	if err != nil {
+		return InactiveMembersOutput{}, err
+	}
+
+	names := c.newUserNameCache(ctx)
+	output := InactiveMembersOutput{
+		ChannelID:    channelID,
+		Since:        newTimestamp(since, c.displayLocation()),
+		Members:      []InactiveMember{},
+		TotalMembers: len(members),
+	}
+	for _, userID := range members {
+		if posters[userID] {
+			output.ActiveCount++
+			continue
+		}
+		output.Members = append(output.Members, InactiveMember{
+			UserID:   userID,
+			UserName: names.Get(userID),
+		})
+	}
+	output.InactiveCount = len(output.Members)
+
+	return output, nil
+}
+
+// conversationMembers returns the IDs of every member of a channel
+func (c *Service) conversationMembers(ctx context.Context, channelID string) ([]string, error) {
+	var members []string
+	cursor := ""
+	for {
+		page, next, err := c.api.GetUsersInConversationContext(ctx, &slack.GetUsersInConversationParameters{
+			ChannelID: channelID,
+			Cursor:    cursor,
+			Limit:     200,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list members: %w", err)
+		}
+		members = append(members, page...)
+		cursor = next
+		if cursor == "" {
+			break
+		}
+	}
+	return members, nil
+}
+
+// recentPosters returns the set of users who posted in a channel after oldest
+func (c *Service) recentPosters(ctx context.Context, channelID, oldest string) (map[string]bool, error) {
+	posters := make(map[string]bool)
+	err := c.walkHistory(ctx, channelID, oldest, "", func(messages []slack.Message) {
+		for _, msg := range messages {
+			if msg.User != "" {
+				posters[msg.User] = true
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return posters, nil
+}
@@ -0,0 +1,81 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+)
+
+func TestInactiveMembers(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	mock.addHandler("/conversations.members", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok":                true,
+			"members":           []string{"U111", "U222"},
+			"response_metadata": map[string]string{"next_cursor": ""},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	var gotOldest string
+	mock.addHandler("/conversations.history", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		gotOldest = r.FormValue("oldest")
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"type": "message", "user": "U111", "text": "hello", "ts": "1700000100.000001"},
+				{"type": "message", "user": "U111", "text": "again", "ts": "1700000200.000001"},
+			},
+			"has_more":          false,
+			"response_metadata": map[string]string{"next_cursor": ""},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/users.info", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		names := map[string]string{"U111": "alice", "U222": "bob"}
+		userID := r.FormValue("user")
+		response := map[string]interface{}{
+			"ok":   true,
+			"user": map[string]interface{}{"id": userID, "name": names[userID]},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	output, err := client.InactiveMembers(context.Background(), InactiveMembersInput{
+		Channel: "C123456789",
+		Since:   "1700000000",
+	})
+	if err != nil {
+		t.Fatalf("InactiveMembers failed: %v", err)
+	}
+
+	if gotOldest != "1700000000" {
+		t.Errorf("oldest: got %q, want 1700000000", gotOldest)
+	}
+
+	want := []InactiveMember{{UserID: "U222", UserName: "bob"}}
+	if len(output.Members) != len(want) {
+		t.Fatalf("Members: got %+v, want %+v", output.Members, want)
+	}
+	if output.Members[0] != want[0] {
+		t.Errorf("Members[0]: got %+v, want %+v", output.Members[0], want[0])
+	}
+
+	if output.TotalMembers != 2 || output.ActiveCount != 1 || output.InactiveCount != 1 {
+		t.Errorf("counts: got total=%d active=%d inactive=%d, want 2/1/1",
+			output.TotalMembers, output.ActiveCount, output.InactiveCount)
+	}
+}
@@ -0,0 +1,124 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// LargeFilesInput defines input for finding large files in a channel
+type LargeFilesInput struct {
+	Channel  string `json:"channel" jsonschema:"Channel ID or name (e.g., C1234567890 or #general)"`
+	MinBytes int    `json:"min_bytes,omitempty" jsonschema:"Only include files at least this many bytes (default 10485760, i.e. 10 MB)"`
+	Oldest   string `json:"oldest,omitempty" jsonschema:"Start of time range (Unix timestamp)"`
+	Latest   string `json:"latest,omitempty" jsonschema:"End of time range (Unix timestamp)"`
+
+	WorkspaceSelector
+}
+
+// LargeFileInfo represents a file shared in a channel
+type LargeFileInfo struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Title     string    `json:"title,omitempty"`
+	Filetype  string    `json:"filetype,omitempty"`
+	Bytes     int       `json:"bytes"`
+	User      string    `json:"user"`
+	UserName  string    `json:"user_name,omitempty"`
+	Created   Timestamp `json:"created"`
+	Permalink string    `json:"permalink,omitempty"`
+}
+
+// LargeFilesOutput contains the matching files, largest first
+type LargeFilesOutput struct {
+	ChannelID  string          `json:"channel_id"`
+	Files      []LargeFileInfo `json:"files"`
+	TotalCount int             `json:"total_count"`
+	TotalBytes int64           `json:"total_bytes"`
+}
+
+// LargeFiles lists files in a channel at or above a size threshold, sorted by size descending
+func (c *Service) LargeFiles(ctx context.Context, input LargeFilesInput) (LargeFilesOutput, error) {
+	channelID, err := c.GetChannelID(ctx, input.Channel)
+	if err != nil {
+		return LargeFilesOutput{}, err
+	}
+
+	minBytes := 10 * 1024 * 1024
+	if input.MinBytes > 0 {
+		minBytes = input.MinBytes
+	}
+
+	params := slack.NewGetFilesParameters()
+	params.Channel = channelID
+	params.Count = 100
+	if input.Oldest != "" {
+		from, err := parseUnixSeconds(input.Oldest)
+		if err != nil {
+			return LargeFilesOutput{}, fmt.Errorf("invalid oldest: %w", err)
+		}
+		params.TimestampFrom = slack.JSONTime(from)
+	}
+	if input.Latest != "" {
+		to, err := parseUnixSeconds(input.Latest)
+		if err != nil {
+			return LargeFilesOutput{}, fmt.Errorf("invalid latest: %w", err)
+		}
+		params.TimestampTo = slack.JSONTime(to)
+	}
+
+	var large []slack.File
+	for {
+		files, paging, err := c.api.GetFilesContext(ctx, params)
+		if err != nil {
+			return LargeFilesOutput{}, fmt.Errorf("failed to list files: %w", err)
+		}
+
+		for _, f := range files {
+			if f.Size >= minBytes {
+				large = append(large, f)
+			}
+		}
+
+		if paging == nil || paging.Page >= paging.Pages {
+			break
+		}
+		params.Page = paging.Page + 1
+	}
+
+	sort.SliceStable(large, func(i, j int) bool { return large[i].Size > large[j].Size })
+
+	names := c.newUserNameCache(ctx)
+	output := LargeFilesOutput{
+		ChannelID:  channelID,
+		Files:      make([]LargeFileInfo, 0, len(large)),
+		TotalCount: len(large),
+	}
+	for _, f := range large {
+		output.TotalBytes += int64(f.Size)
+		output.Files = append(output.Files, LargeFileInfo{
+			ID:        f.ID,
+			Name:      f.Name,
+			Title:     f.Title,
+			Filetype:  f.Filetype,
+			Bytes:     f.Size,
+			User:      f.User,
+			UserName:  names.Get(f.User),
+			Created:   newTimestamp(strconv.FormatInt(int64(f.Created), 10), c.displayLocation()),
+			Permalink: f.Permalink,
+		})
+	}
+
+	return output, nil
+}
+
+// parseUnixSeconds parses a Unix timestamp, ignoring any fractional part
+// (e.g. "1234567890.123456" yields 1234567890).
+func parseUnixSeconds(ts string) (int64, error) {
+	sec, _, _ := strings.Cut(ts, ".")
+	return strconv.ParseInt(sec, 10, 64)
+}
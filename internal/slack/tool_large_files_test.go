@@ -0,0 +1,107 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+
+	"go.uber.org/zap/zaptest"
+)
+
+func TestLargeFiles(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	var gotChannel, gotFrom string
+	mock.addHandler("/files.list", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		gotChannel = r.FormValue("channel")
+		gotFrom = r.FormValue("ts_from")
+
+		var response map[string]interface{}
+		switch r.FormValue("page") {
+		case "2":
+			response = map[string]interface{}{
+				"ok": true,
+				"files": []map[string]interface{}{
+					{"id": "F3", "name": "dump.tar.gz", "size": 80_000_000, "user": "U222", "created": 1700000200},
+				},
+				"paging": map[string]int{"count": 1, "total": 3, "page": 2, "pages": 2},
+			}
+		default:
+			response = map[string]interface{}{
+				"ok": true,
+				"files": []map[string]interface{}{
+					{"id": "F1", "name": "small.txt", "size": 100, "user": "U111", "created": 1700000000},
+					{"id": "F2", "name": "video.mp4", "size": 50_000_000, "user": "U111", "created": 1700000100},
+				},
+				"paging": map[string]int{"count": 2, "total": 3, "page": 1, "pages": 2},
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/users.info", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		names := map[string]string{"U111": "alice", "U222": "bob"}
+		userID := r.FormValue("user")
+		response := map[string]interface{}{
+			"ok":   true,
+			"user": map[string]interface{}{"id": userID, "name": names[userID]},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	output, err := client.LargeFiles(context.Background(), LargeFilesInput{
+		Channel: "C123456789",
+		Oldest:  "1690000000.000100",
+	})
+	if err != nil {
+		t.Fatalf("LargeFiles failed: %v", err)
+	}
+
+	if gotChannel != "C123456789" {
+		t.Errorf("channel: got %q, want C123456789", gotChannel)
+	}
+	if gotFrom != "1690000000" {
+		t.Errorf("ts_from: got %q, want 1690000000", gotFrom)
+	}
+
+	tests := []struct {
+		id       string
+		bytes    int
+		userName string
+	}{
+		{"F3", 80_000_000, "bob"},
+		{"F2", 50_000_000, "alice"},
+	}
+	if output.TotalCount != len(tests) {
+		t.Fatalf("TotalCount: got %d, want %d", output.TotalCount, len(tests))
+	}
+	for i, tt := range tests {
+		got := output.Files[i]
+		if got.ID != tt.id || got.Bytes != tt.bytes || got.UserName != tt.userName {
+			t.Errorf("Files[%d]: got %s/%d/%s, want %s/%d/%s", i, got.ID, got.Bytes, got.UserName, tt.id, tt.bytes, tt.userName)
+		}
+	}
+
+	if output.TotalBytes != 130_000_000 {
+		t.Errorf("TotalBytes: got %d, want 130000000", output.TotalBytes)
+	}
+}
+
+func TestLargeFiles_InvalidRange(t *testing.T) {
+	client := newServiceWithIndex(nil, nil, zaptest.NewLogger(t), nil)
+
+	_, err := client.LargeFiles(context.Background(), LargeFilesInput{Channel: "C123456789", Latest: "yesterday"})
+	if err == nil {
+		t.Error("expected error for invalid latest timestamp")
+	}
+}
@@ -0,0 +1,86 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/slack-go/slack"
+)
+
+// ListAllScheduledInput defines input for listing scheduled messages across all channels
+type ListAllScheduledInput struct {
+	Channel string `json:"channel,omitempty" jsonschema:"Only list messages scheduled in this channel (ID or name). Omit to list every channel"`
+
+	WorkspaceSelector
+}
+
+// ScheduledMessageInfo represents a pending scheduled message
+type ScheduledMessageInfo struct {
+	ID            string `json:"id"`
+	ChannelID     string `json:"channel_id"`
+	ChannelName   string `json:"channel_name,omitempty"`
+	PostAt        string `json:"post_at"`
+	PostAtDisplay string `json:"post_at_display,omitempty"`
+	Text          string `json:"text"`
+}
+
+// ListAllScheduledOutput contains all pending scheduled messages
+type ListAllScheduledOutput struct {
+	Messages   []ScheduledMessageInfo `json:"messages"`
+	TotalCount int                    `json:"total_count"`
+}
+
+// ListAllScheduled lists pending scheduled messages in every channel the user can see
+func (c *Service) ListAllScheduled(ctx context.Context, input ListAllScheduledInput) (ListAllScheduledOutput, error) {
+	var channelID string
+	if input.Channel != "" {
+		var err error
+		if channelID, err = c.GetChannelID(ctx, input.Channel, messageChannelTypes...); err != nil {
+			return ListAllScheduledOutput{}, err
+		}
+	}
+
+	var scheduled []slack.ScheduledMessage
+	cursor := ""
+	for {
+		page, next, err := c.api.GetScheduledMessagesContext(ctx, &slack.GetScheduledMessagesParameters{
+			Channel: channelID,
+			Cursor:  cursor,
+			Limit:   100,
+		})
+		if err != nil {
+			return ListAllScheduledOutput{}, fmt.Errorf("failed to list scheduled messages: %w", err)
+		}
+		scheduled = append(scheduled, page...)
+		cursor = next
+		if cursor == "" {
+			break
+		}
+	}
+
+	output := ListAllScheduledOutput{
+		Messages:   make([]ScheduledMessageInfo, 0, len(scheduled)),
+		TotalCount: len(scheduled),
+	}
+
+	channelIDs := make([]string, 0, len(scheduled))
+	for _, msg := range scheduled {
+		channelIDs = append(channelIDs, msg.Channel)
+	}
+	channelNames := c.resolveChannelNames(ctx, channelIDs)
+
+	for _, msg := range scheduled {
+		postAt := strconv.Itoa(msg.PostAt)
+		output.Messages = append(output.Messages, ScheduledMessageInfo{
+			ID:            msg.ID,
+			ChannelID:     msg.Channel,
+			ChannelName:   channelNames[msg.Channel],
+			PostAt:        postAt,
+			PostAtDisplay: formatSlackTimestamp(postAt, c.displayLocation()),
+			Text:          msg.Text,
+		})
+	}
+
+	return output, nil
+}
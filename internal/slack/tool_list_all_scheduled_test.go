@@ -0,0 +1,151 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+)
+
+func TestListAllScheduled(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	var gotChannelFilter string
+	mock.addHandler("/chat.scheduledMessages.list", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		gotChannelFilter = r.FormValue("channel")
+		response := map[string]interface{}{
+			"ok": true,
+			"scheduled_messages": []map[string]interface{}{
+				{
+					"id":           "Q111",
+					"channel_id":   "C111111111",
+					"post_at":      1700000000,
+					"date_created": 1690000000,
+					"text":         "Standup reminder",
+				},
+				{
+					"id":           "Q222",
+					"channel_id":   "C222222222",
+					"post_at":      1700003600,
+					"date_created": 1690000000,
+					"text":         "Release notes",
+				},
+			},
+			"response_metadata": map[string]string{
+				"next_cursor": "",
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/conversations.info", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		channelID := r.FormValue("channel")
+		names := map[string]string{
+			"C111111111": "general",
+			"C222222222": "releases",
+		}
+		response := map[string]interface{}{
+			"ok": true,
+			"channel": map[string]interface{}{
+				"id":   channelID,
+				"name": names[channelID],
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	output, err := client.ListAllScheduled(context.Background(), ListAllScheduledInput{})
+	if err != nil {
+		t.Fatalf("ListAllScheduled failed: %v", err)
+	}
+
+	if gotChannelFilter != "" {
+		t.Errorf("channel filter: got %q, want empty", gotChannelFilter)
+	}
+
+	if output.TotalCount != 2 {
+		t.Fatalf("TotalCount: got %d, want 2", output.TotalCount)
+	}
+
+	tests := []struct {
+		id          string
+		channelID   string
+		channelName string
+		postAt      string
+		text        string
+	}{
+		{"Q111", "C111111111", "general", "1700000000", "Standup reminder"},
+		{"Q222", "C222222222", "releases", "1700003600", "Release notes"},
+	}
+
+	for i, want := range tests {
+		got := output.Messages[i]
+		if got.ID != want.id {
+			t.Errorf("Messages[%d].ID: got %q, want %q", i, got.ID, want.id)
+		}
+		if got.ChannelID != want.channelID {
+			t.Errorf("Messages[%d].ChannelID: got %q, want %q", i, got.ChannelID, want.channelID)
+		}
+		if got.ChannelName != want.channelName {
+			t.Errorf("Messages[%d].ChannelName: got %q, want %q", i, got.ChannelName, want.channelName)
+		}
+		if got.PostAt != want.postAt {
+			t.Errorf("Messages[%d].PostAt: got %q, want %q", i, got.PostAt, want.postAt)
+		}
+		if got.Text != want.text {
+			t.Errorf("Messages[%d].Text: got %q, want %q", i, got.Text, want.text)
+		}
+	}
+}
+
+func TestListAllScheduled_ChannelFilter(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	var gotChannelFilter string
+	mock.addHandler("/chat.scheduledMessages.list", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		gotChannelFilter = r.FormValue("channel")
+		response := map[string]interface{}{
+			"ok": true,
+			"scheduled_messages": []map[string]interface{}{
+				{"id": "Q111", "channel_id": "C111111111", "post_at": 1700000000, "text": "Standup reminder"},
+			},
+			"response_metadata": map[string]string{"next_cursor": ""},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/conversations.info", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok":      true,
+			"channel": map[string]interface{}{"id": "C111111111", "name": "general"},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	output, err := client.ListAllScheduled(context.Background(), ListAllScheduledInput{Channel: "C111111111"})
+	if err != nil {
+		t.Fatalf("ListAllScheduled failed: %v", err)
+	}
+	if gotChannelFilter != "C111111111" {
+		t.Errorf("channel filter: got %q, want %q", gotChannelFilter, "C111111111")
+	}
+	if output.TotalCount != 1 || output.Messages[0].ID != "Q111" {
+		t.Errorf("Messages: got %+v, want Q111 only", output.Messages)
+	}
+}
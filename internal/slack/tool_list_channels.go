@@ -1,9 +1,14 @@
 package slack
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/slack-go/slack"
 )
@@ -13,6 +18,16 @@ type ListChannelsInput struct {
 	Types  string `json:"types,omitempty" jsonschema:"Channel types: public_channel, private_channel, mpim, im (comma-separated). Default: public_channel, private_channel"`
 	Limit  int    `json:"limit,omitempty" jsonschema:"Max channels to return (default 100)"`
 	Cursor string `json:"cursor,omitempty" jsonschema:"Pagination cursor for fetching more results"`
+	SortBy string `json:"sort_by,omitempty" jsonschema:"Order of results: name (default, A-Z), members (most first), or created (newest first). Sorting applies within the returned page"`
+
+	MemberOnly      bool `json:"member_only,omitempty" jsonschema:"Only return channels you are a member of. Filtering is per page, so a page may hold fewer than limit channels"`
+	ExcludeArchived bool `json:"exclude_archived,omitempty" jsonschema:"Leave out archived channels (included by default)"`
+	ResolveCreators bool `json:"resolve_creators,omitempty" jsonschema:"Look up the user name of each channel's creator (one extra API call per distinct creator)"`
+
+	Compress  bool `json:"compress,omitempty" jsonschema:"Write gzip-compressed output (.json.gz)"`
+	ChunkSize int  `json:"chunk_size,omitempty" jsonschema:"Split output into numbered files of at most this many channels each"`
+
+	WorkspaceSelector
 }
 
 // ChannelInfo represents a Slack channel
@@ -24,15 +39,23 @@ type ChannelInfo struct {
 	MemberCount int    `json:"member_count"`
 	IsPrivate   bool   `json:"is_private"`
 	IsArchived  bool   `json:"is_archived"`
+	IsMember    bool   `json:"is_member"`
+
+	Created     Timestamp `json:"created,omitempty"`
+	Creator     string    `json:"creator,omitempty"`
+	CreatorName string    `json:"creator_name,omitempty"`
 }
 
-// ListChannelsOutput contains a summary and file reference (to save tokens)
+// ListChannelsOutput contains a summary and file reference (to save tokens).
+// Small results are returned inline in Channels and no file is written.
 type ListChannelsOutput struct {
-	File         FileRef      `json:"file"`
-	TotalCount   int          `json:"total_count"`
-	FirstChannel *ChannelInfo `json:"first_channel,omitempty"`
-	LastChannel  *ChannelInfo `json:"last_channel,omitempty"`
-	NextCursor   string       `json:"next_cursor,omitempty"`
+	File         *FileRef      `json:"file,omitempty"`
+	Files        []FileRef     `json:"files,omitempty"`
+	Channels     []ChannelInfo `json:"channels,omitempty"`
+	TotalCount   int           `json:"total_count"`
+	FirstChannel *ChannelInfo  `json:"first_channel,omitempty"`
+	LastChannel  *ChannelInfo  `json:"last_channel,omitempty"`
+	NextCursor   string        `json:"next_cursor,omitempty"`
 }
 
 // ListChannels lists channels the user has access to
@@ -45,15 +68,21 @@ func (c *Service) ListChannels(ctx context.Context, input ListChannelsInput) (Li
 		}
 	}
 
+	sortChannels, err := channelSorter(input.SortBy)
+	if err != nil {
+		return ListChannelsOutput{}, err
+	}
+
 	limit := 100
 	if input.Limit > 0 && input.Limit <= 1000 {
 		limit = input.Limit
 	}
 
 	params := &slack.GetConversationsParameters{
-		Types:  types,
-		Limit:  limit,
-		Cursor: input.Cursor,
+		Types:           types,
+		Limit:           limit,
+		Cursor:          input.Cursor,
+		ExcludeArchived: input.ExcludeArchived,
 	}
 
 	channels, cursor, err := c.listConversations(ctx, params)
@@ -61,30 +90,55 @@ func (c *Service) ListChannels(ctx context.Context, input ListChannelsInput) (Li
 		return ListChannelsOutput{}, fmt.Errorf("failed to list channels: %w", err)
 	}
 
+	names := c.newUserNameCache(ctx)
+	var dmUsers []string
+	for _, ch := range channels {
+		if ch.IsIM {
+			dmUsers = append(dmUsers, ch.User)
+		}
+	}
+	names.Prefetch(dmUsers)
+
 	channelInfos := make([]ChannelInfo, 0, len(channels))
 	for _, ch := range channels {
-		channelInfos = append(channelInfos, ChannelInfo{
-			ID:          ch.ID,
-			Name:        ch.Name,
-			Topic:       ch.Topic.Value,
-			Purpose:     ch.Purpose.Value,
-			MemberCount: ch.NumMembers,
-			IsPrivate:   ch.IsPrivate,
-			IsArchived:  ch.IsArchived,
-		})
-	}
-
-	fileRef, err := c.responses.WriteJSON("channels", channelInfos)
-	if err != nil {
-		return ListChannelsOutput{}, fmt.Errorf("failed to write response: %w", err)
+		if input.MemberOnly && !ch.IsMember {
+			continue
+		}
+		channelInfos = append(channelInfos, newChannelInfo(ch, names, c.displayLocation()))
 	}
 
+	if input.ResolveCreators {
+		creators := make([]string, 0, len(channelInfos))
+		for _, ch := range channelInfos {
+			creators = append(creators, ch.Creator)
+		}
+		names.Prefetch(creators)
+		for i := range channelInfos {
+			channelInfos[i].CreatorName = names.Get(channelInfos[i].Creator)
+		}
+	}
+
+	sortChannels(channelInfos)
+
 	output := ListChannelsOutput{
-		File:       fileRef,
 		TotalCount: len(channelInfos),
 		NextCursor: cursor,
 	}
 
+	// Compression and chunking only make sense for files, so they force one
+	if !input.Compress && input.ChunkSize <= 0 && c.fitsInline(channelInfos) {
+		output.Channels = channelInfos
+	} else {
+		refs, err := writeJSONList(c.responses, "channels", channelInfos, input.ChunkSize, input.Compress)
+		if err != nil {
+			return ListChannelsOutput{}, fmt.Errorf("failed to write response: %w", err)
+		}
+		output.File = &refs[0]
+		if len(refs) > 1 {
+			output.Files = refs
+		}
+	}
+
 	if len(channelInfos) > 0 {
 		output.FirstChannel = &channelInfos[0]
 		output.LastChannel = &channelInfos[len(channelInfos)-1]
@@ -92,3 +146,75 @@ func (c *Service) ListChannels(ctx context.Context, input ListChannelsInput) (Li
 
 	return output, nil
 }
+
+// newChannelInfo converts a Slack channel to its tool representation,
+// formatting its creation time in loc. DMs and group DMs are given readable
+// names; names resolves DM partners and may be nil when no DMs are expected.
+func newChannelInfo(ch slack.Channel, names *userNameCache, loc *time.Location) ChannelInfo {
+	var created Timestamp
+	if ch.Created > 0 {
+		created = newTimestamp(strconv.FormatInt(int64(ch.Created), 10), loc)
+	}
+	name := ch.Name
+	if dm := directMessageName(ch, names); dm != "" {
+		name = dm
+	}
+	return ChannelInfo{
+		ID:          ch.ID,
+		Name:        name,
+		Topic:       ch.Topic.Value,
+		Purpose:     ch.Purpose.Value,
+		MemberCount: ch.NumMembers,
+		IsPrivate:   ch.IsPrivate,
+		IsArchived:  ch.IsArchived,
+		IsMember:    ch.IsMember,
+		Created:     created,
+		Creator:     ch.Creator,
+	}
+}
+
+// mpdmSuffix matches the numeric suffix Slack appends to group DM names
+var mpdmSuffix = regexp.MustCompile(`-\d+$`)
+
+// directMessageName returns "DM with @alice" for an im and
+// "Group DM: @alice, @bob" for an mpim, or "" for other conversations.
+// Group DM members come from the mpdm-alice--bob-1 name Slack assigns.
+func directMessageName(ch slack.Channel, names *userNameCache) string {
+	switch {
+	case ch.IsIM:
+		user := ch.User
+		if names != nil {
+			if name := names.Get(ch.User); name != "" {
+				user = name
+			}
+		}
+		return "DM with @" + user
+	case ch.IsMpIM && strings.HasPrefix(ch.Name, "mpdm-"):
+		handles := strings.Split(mpdmSuffix.ReplaceAllString(strings.TrimPrefix(ch.Name, "mpdm-"), ""), "--")
+		for i, h := range handles {
+			handles[i] = "@" + h
+		}
+		return "Group DM: " + strings.Join(handles, ", ")
+	}
+	return ""
+}
+
+// channelSorter returns a function that sorts channels in the order named by sortBy
+func channelSorter(sortBy string) (func([]ChannelInfo), error) {
+	var cmpFn func(a, b ChannelInfo) int
+	switch sortBy {
+	case "", "name":
+		cmpFn = func(a, b ChannelInfo) int { return strings.Compare(a.Name, b.Name) }
+	case "members":
+		cmpFn = func(a, b ChannelInfo) int { return cmp.Compare(b.MemberCount, a.MemberCount) }
+	case "created":
+		cmpFn = func(a, b ChannelInfo) int {
+			ta, _ := time.Parse(time.RFC3339, string(a.Created))
+			tb, _ := time.Parse(time.RFC3339, string(b.Created))
+			return tb.Compare(ta)
+		}
+	default:
+		return nil, fmt.Errorf("invalid sort_by %q: must be name, members, or created", sortBy)
+	}
+	return func(channels []ChannelInfo) { slices.SortStableFunc(channels, cmpFn) }, nil
+}
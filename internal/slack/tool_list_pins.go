@@ -0,0 +1,71 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/slack-go/slack"
+)
+
+// ListPinsInput defines input for listing a channel's pinned messages
+type ListPinsInput struct {
+	Channel string `json:"channel" jsonschema:"Channel ID or name (e.g., C1234567890 or #general)"`
+
+	WorkspaceSelector
+}
+
+// ListPinsOutput contains the pinned messages, inline when small or in File otherwise
+type ListPinsOutput struct {
+	ChannelID  string        `json:"channel_id"`
+	Messages   []MessageInfo `json:"messages,omitempty"`
+	File       *FileRef      `json:"file,omitempty"`
+	TotalCount int           `json:"total_count"`
+}
+
+// ListPins lists the messages pinned in a channel. Pinned files are skipped.
+func (c *Service) ListPins(ctx context.Context, input ListPinsInput) (ListPinsOutput, error) {
+	channelID, err := c.GetChannelID(ctx, input.Channel)
+	if err != nil {
+		return ListPinsOutput{}, err
+	}
+
+	messages, err := c.pinnedMessages(ctx, channelID)
+	if err != nil {
+		return ListPinsOutput{}, err
+	}
+
+	output := ListPinsOutput{
+		ChannelID:  channelID,
+		TotalCount: len(messages),
+	}
+	if c.fitsInline(messages) {
+		output.Messages = messages
+		return output, nil
+	}
+
+	ref, err := c.responses.WriteJSON("pins", messages)
+	if err != nil {
+		return ListPinsOutput{}, fmt.Errorf("failed to write response: %w", err)
+	}
+	output.File = &ref
+	return output, nil
+}
+
+// pinnedMessages returns the messages pinned in a channel, skipping pinned files
+func (c *Service) pinnedMessages(ctx context.Context, channelID string) ([]MessageInfo, error) {
+	items, _, err := c.api.ListPinsContext(ctx, channelID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pins: %w", err)
+	}
+
+	names := c.newUserNameCache(ctx)
+	messages := make([]MessageInfo, 0, len(items))
+	for _, item := range items {
+		if item.Type != slack.TYPE_MESSAGE || item.Message == nil {
+			continue
+		}
+		msg := *item.Message
+		messages = append(messages, buildMessageInfo(msg, msg.ThreadTimestamp, names.Get(msg.User), c.displayLocation()))
+	}
+	return messages, nil
+}
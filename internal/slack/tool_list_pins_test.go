@@ -0,0 +1,74 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+)
+
+func TestListPins(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	var gotChannel string
+	mock.addHandler("/pins.list", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		gotChannel = r.FormValue("channel")
+		response := map[string]interface{}{
+			"ok": true,
+			"items": []map[string]interface{}{
+				{
+					"type":    "message",
+					"channel": "C123456789",
+					"message": map[string]interface{}{
+						"type": "message",
+						"user": "U111",
+						"text": "Runbook: https://example.com/runbook",
+						"ts":   "1700000000.000100",
+					},
+				},
+				{
+					"type":    "file",
+					"channel": "C123456789",
+					"file":    map[string]interface{}{"id": "F111", "name": "diagram.png"},
+				},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/users.info", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok":   true,
+			"user": map[string]interface{}{"id": "U111", "name": "alice"},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	output, err := client.ListPins(context.Background(), ListPinsInput{Channel: "C123456789"})
+	if err != nil {
+		t.Fatalf("ListPins failed: %v", err)
+	}
+
+	if gotChannel != "C123456789" {
+		t.Errorf("channel: got %q, want C123456789", gotChannel)
+	}
+	if output.TotalCount != 1 || len(output.Messages) != 1 {
+		t.Fatalf("messages: got total=%d inline=%d, want 1/1", output.TotalCount, len(output.Messages))
+	}
+
+	got := output.Messages[0]
+	if got.Text != "Runbook: https://example.com/runbook" || got.UserName != "alice" || got.RawTimestamp != "1700000000.000100" {
+		t.Errorf("Messages[0]: got %+v", got)
+	}
+	if output.File != nil {
+		t.Errorf("File: got %+v, want nil for inline result", output.File)
+	}
+}
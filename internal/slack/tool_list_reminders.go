@@ -0,0 +1,63 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// ListRemindersInput defines input for listing the user's reminders
+type ListRemindersInput struct {
+	IncludeComplete bool `json:"include_complete,omitempty" jsonschema:"Also return reminders that have been marked complete"`
+
+	WorkspaceSelector
+}
+
+// ReminderInfo represents a reminder created by or for the authenticated user
+type ReminderInfo struct {
+	ID          string `json:"id"`
+	Text        string `json:"text"`
+	Time        string `json:"time,omitempty"`
+	TimeDisplay string `json:"time_display,omitempty"`
+	Recurring   bool   `json:"recurring,omitempty"`
+	Complete    bool   `json:"complete"`
+	Creator     string `json:"creator,omitempty"`
+}
+
+// ListRemindersOutput contains the user's reminders
+type ListRemindersOutput struct {
+	Reminders  []ReminderInfo `json:"reminders"`
+	TotalCount int            `json:"total_count"`
+}
+
+// ListReminders lists reminders created by or for the authenticated user.
+// Completed reminders are left out unless IncludeComplete is set.
+func (c *Service) ListReminders(ctx context.Context, input ListRemindersInput) (ListRemindersOutput, error) {
+	reminders, err := c.api.ListRemindersContext(ctx)
+	if err != nil {
+		return ListRemindersOutput{}, fmt.Errorf("failed to list reminders: %w", err)
+	}
+
+	output := ListRemindersOutput{Reminders: make([]ReminderInfo, 0, len(reminders))}
+	for _, r := range reminders {
+		complete := r.CompleteTS > 0
+		if complete && !input.IncludeComplete {
+			continue
+		}
+		info := ReminderInfo{
+			ID:        r.ID,
+			Text:      r.Text,
+			Recurring: r.Recurring,
+			Complete:  complete,
+			Creator:   r.Creator,
+		}
+		// Recurring reminders have no single time
+		if r.Time > 0 {
+			info.Time = strconv.Itoa(r.Time)
+			info.TimeDisplay = formatSlackTimestamp(info.Time, c.displayLocation())
+		}
+		output.Reminders = append(output.Reminders, info)
+	}
+	output.TotalCount = len(output.Reminders)
+	return output, nil
+}
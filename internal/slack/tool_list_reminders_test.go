@@ -0,0 +1,57 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+)
+
+func TestListReminders(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	mock.addHandler("/reminders.list", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"reminders": []map[string]interface{}{
+				{"id": "Rm111", "creator": "U123456789", "user": "U123456789", "text": "Submit expenses", "recurring": false, "time": 1700000000, "complete_ts": 0},
+				{"id": "Rm222", "creator": "U123456789", "user": "U123456789", "text": "Water plants", "recurring": true, "complete_ts": 0},
+				{"id": "Rm333", "creator": "U987654321", "user": "U123456789", "text": "Review PR", "recurring": false, "time": 1690000000, "complete_ts": 1690000100},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	output, err := client.ListReminders(context.Background(), ListRemindersInput{})
+	if err != nil {
+		t.Fatalf("ListReminders failed: %v", err)
+	}
+	if output.TotalCount != 2 || len(output.Reminders) != 2 {
+		t.Fatalf("Reminders: got %+v, want Rm111 and Rm222", output.Reminders)
+	}
+
+	first := output.Reminders[0]
+	if first.ID != "Rm111" || first.Text != "Submit expenses" || first.Time != "1700000000" || first.TimeDisplay == "" || first.Complete {
+		t.Errorf("Reminders[0]: got %+v", first)
+	}
+	if second := output.Reminders[1]; second.ID != "Rm222" || !second.Recurring || second.Time != "" {
+		t.Errorf("Reminders[1]: got %+v", second)
+	}
+
+	output, err = client.ListReminders(context.Background(), ListRemindersInput{IncludeComplete: true})
+	if err != nil {
+		t.Fatalf("ListReminders failed: %v", err)
+	}
+	if output.TotalCount != 3 {
+		t.Fatalf("TotalCount with include_complete: got %d, want 3", output.TotalCount)
+	}
+	if done := output.Reminders[2]; done.ID != "Rm333" || !done.Complete || done.Creator != "U987654321" {
+		t.Errorf("Reminders[2]: got %+v", done)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/slack-go/slack"
 )
@@ -12,76 +13,175 @@ import (
 type ReadCanvasInput struct {
 	Channel string `json:"channel,omitempty" jsonschema:"Channel ID or name (for channel canvases)"`
 	FileID  string `json:"file_id,omitempty" jsonschema:"Canvas file ID (for standalone canvases)"`
+
+	MaxChunkBytes int `json:"max_chunk_bytes,omitempty" jsonschema:"Split canvases larger than this many bytes into several files at paragraph boundaries. Omit to write one file"`
+
+	WorkspaceSelector
 }
 
-// ReadCanvasOutput contains the canvas content and metadata
+// ReadCanvasOutput contains the canvas content and metadata. When the canvas
+// is split, Files lists every chunk in order and File is the first.
 type ReadCanvasOutput struct {
-	File   FileRef `json:"file"`
-	FileID string  `json:"file_id"`
-	Title  string  `json:"title"`
+	File   FileRef   `json:"file"`
+	Files  []FileRef `json:"files,omitempty"`
+	FileID string    `json:"file_id"`
+	Title  string    `json:"title"`
+
+	WordCount      int `json:"word_count"`
+	ParagraphCount int `json:"paragraph_count"`
 }
 
 // ReadCanvas reads a Slack canvas and returns its content as plain text
 func (c *Service) ReadCanvas(ctx context.Context, input ReadCanvasInput) (ReadCanvasOutput, error) {
-	if input.Channel == "" && input.FileID == "" {
-		return ReadCanvasOutput{}, fmt.Errorf("either channel or file_id is required")
-	}
-	if input.Channel != "" && input.FileID != "" {
-		return ReadCanvasOutput{}, fmt.Errorf("provide either channel or file_id, not both")
+	canvas, err := c.fetchCanvas(ctx, input.Channel, input.FileID)
+	if err != nil {
+		return ReadCanvasOutput{}, err
 	}
 
-	fileID := input.FileID
+	output := ReadCanvasOutput{
+		FileID:         canvas.FileID,
+		Title:          canvas.Title,
+		WordCount:      computeTextStats(canvas.Text).WordCount,
+		ParagraphCount: countParagraphs(canvas.Text),
+	}
 
-	if input.Channel != "" {
-		channelID, err := c.GetChannelID(input.Channel)
+	if input.MaxChunkBytes <= 0 || len(canvas.Text) <= input.MaxChunkBytes {
+		output.File, err = c.responses.WriteText("canvas", canvas.Text)
 		if err != nil {
-			return ReadCanvasOutput{}, err
+			return ReadCanvasOutput{}, fmt.Errorf("failed to write response: %w", err)
 		}
+		return output, nil
+	}
 
-		ch, err := c.getConversationInfo(ctx, channelID)
+	for i, chunk := range chunkCanvasText(canvas.Text, input.MaxChunkBytes) {
+		ref, err := c.responses.WriteText(fmt.Sprintf("canvas-part%03d", i+1), chunk)
 		if err != nil {
-			return ReadCanvasOutput{}, fmt.Errorf("failed to get channel info: %w", err)
+			return ReadCanvasOutput{}, fmt.Errorf("failed to write response: %w", err)
+		}
+		output.Files = append(output.Files, ref)
+	}
+	output.File = output.Files[0]
+	return output, nil
+}
+
+// countParagraphs counts the blank-line separated blocks in text produced by
+// stripHTML, not counting headings. A list counts as one paragraph.
+func countParagraphs(text string) int {
+	n := 0
+	for _, para := range strings.Split(text, "\n\n") {
+		if para != "" && !strings.HasPrefix(para, "#") {
+			n++
 		}
+	}
+	return n
+}
 
-		if ch.Properties == nil || ch.Properties.Canvas.FileId == "" {
-			return ReadCanvasOutput{}, fmt.Errorf("channel has no canvas")
+// chunkCanvasText splits text produced by stripHTML into chunks of at most
+// maxBytes, breaking only between paragraphs. A chunk that starts inside a
+// section repeats that section's heading, marked "(continued)", when that
+// still fits within maxBytes. A single paragraph longer than maxBytes gets a
+// chunk of its own.
+func chunkCanvasText(text string, maxBytes int) []string {
+	var chunks []string
+	var cur strings.Builder
+	heading := ""
+	for _, para := range strings.Split(text, "\n\n") {
+		isHeading := strings.HasPrefix(para, "#")
+		if cur.Len() > 0 && cur.Len()+2+len(para) > maxBytes {
+			chunks = append(chunks, cur.String())
+			cur.Reset()
+			if heading != "" && !isHeading {
+				if prefix := heading + " (continued)"; len(prefix)+2+len(para) <= maxBytes {
+					cur.WriteString(prefix)
+				}
+			}
+		}
+		if cur.Len() > 0 {
+			cur.WriteString("\n\n")
+		}
+		cur.WriteString(para)
+		if isHeading {
+			heading = para
 		}
-		fileID = ch.Properties.Canvas.FileId
 	}
+	if cur.Len() > 0 {
+		chunks = append(chunks, cur.String())
+	}
+	return chunks
+}
 
-	var file *slack.File
-	err := withRetry(ctx, c.logger, func() error {
-		var e error
-		file, _, _, e = c.api.GetFileInfoContext(ctx, fileID, 0, 0)
-		return e
-	})
+// canvasContent holds a downloaded canvas converted to plain text
+type canvasContent struct {
+	FileID string
+	Title  string
+	Text   string
+}
+
+// channelCanvas returns the ID of a channel and its canvas properties, or a
+// nil canvas if the channel has none
+func (c *Service) channelCanvas(ctx context.Context, channel string) (string, *slack.Canvas, error) {
+	channelID, err := c.GetChannelID(ctx, channel)
 	if err != nil {
-		return ReadCanvasOutput{}, fmt.Errorf("failed to get file info: %w", err)
+		return "", nil, err
 	}
 
-	if file.Filetype != "quip" {
-		return ReadCanvasOutput{}, fmt.Errorf("file is not a canvas (filetype %q, expected \"quip\")", file.Filetype)
+	ch, err := c.getConversationInfo(ctx, channelID)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get channel info: %w", err)
 	}
 
-	var buf bytes.Buffer
-	err = withRetry(ctx, c.logger, func() error {
-		buf.Reset()
-		return c.api.GetFileContext(ctx, file.URLPrivateDownload, &buf)
-	})
+	if ch.Properties == nil || ch.Properties.Canvas.FileId == "" {
+		return channelID, nil, nil
+	}
+	return channelID, &ch.Properties.Canvas, nil
+}
+
+// getFileInfo fetches a file's metadata without its comments
+func (c *Service) getFileInfo(ctx context.Context, fileID string) (*slack.File, error) {
+	file, _, _, err := c.api.GetFileInfoContext(ctx, fileID, 0, 0)
 	if err != nil {
-		return ReadCanvasOutput{}, fmt.Errorf("failed to download canvas: %w", err)
+		return nil, fmt.Errorf("failed to get file info: %w", err)
 	}
+	return file, nil
+}
 
-	text := stripHTML(buf.String())
+// fetchCanvas resolves a canvas by channel or file ID, downloads it, and strips it to plain text
+func (c *Service) fetchCanvas(ctx context.Context, channel, fileID string) (canvasContent, error) {
+	if channel == "" && fileID == "" {
+		return canvasContent{}, fmt.Errorf("either channel or file_id is required")
+	}
+	if channel != "" && fileID != "" {
+		return canvasContent{}, fmt.Errorf("provide either channel or file_id, not both")
+	}
 
-	ref, err := c.responses.WriteText("canvas", text)
+	if channel != "" {
+		_, canvas, err := c.channelCanvas(ctx, channel)
+		if err != nil {
+			return canvasContent{}, err
+		}
+		if canvas == nil {
+			return canvasContent{}, fmt.Errorf("channel has no canvas")
+		}
+		fileID = canvas.FileId
+	}
+
+	file, err := c.getFileInfo(ctx, fileID)
 	if err != nil {
-		return ReadCanvasOutput{}, fmt.Errorf("failed to write response: %w", err)
+		return canvasContent{}, err
+	}
+
+	if file.Filetype != "quip" {
+		return canvasContent{}, fmt.Errorf("file is not a canvas (filetype %q, expected \"quip\")", file.Filetype)
+	}
+
+	var buf bytes.Buffer
+	if err := c.api.GetFileContext(ctx, file.URLPrivateDownload, &buf); err != nil {
+		return canvasContent{}, fmt.Errorf("failed to download canvas: %w", err)
 	}
 
-	return ReadCanvasOutput{
-		File:   ref,
+	return canvasContent{
 		FileID: fileID,
 		Title:  file.Title,
+		Text:   stripHTML(buf.String()),
 	}, nil
 }
@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestReadCanvas_ByFileID(t *testing.T) {
@@ -77,6 +78,133 @@ func TestReadCanvas_ByFileID(t *testing.T) {
 	}
 }
 
+func TestReadCanvas_Counts(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	canvasHTML := "<h1>Release plan</h1><p>Ship the beta on Friday.</p>" +
+		"<h2>Risks</h2><ul><li>Migrations</li><li>Load</li></ul><p>Owner: the platform team.</p>"
+
+	mock.addHandler("/files.info", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"file": map[string]interface{}{
+				"id":                   "F123CANVAS",
+				"title":                "Release plan",
+				"filetype":             "quip",
+				"url_private_download": mock.server.URL + "/files/F123CANVAS/download",
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+	mock.addHandler("/files/F123CANVAS/download", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(canvasHTML))
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	output, err := client.ReadCanvas(context.Background(), ReadCanvasInput{FileID: "F123CANVAS"})
+	if err != nil {
+		t.Fatalf("ReadCanvas failed: %v", err)
+	}
+
+	// Release plan / Ship the beta on Friday. / Risks / Migrations Load / Owner: the platform team.
+	if output.WordCount != 14 {
+		t.Errorf("WordCount: got %d, want 14", output.WordCount)
+	}
+	// The sentence, the list and the owner line; headings are not paragraphs
+	if output.ParagraphCount != 3 {
+		t.Errorf("ParagraphCount: got %d, want 3", output.ParagraphCount)
+	}
+}
+
+func TestReadCanvas_MaxChunkBytes(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	first := strings.Repeat("a", 40)
+	second := strings.Repeat("b", 40)
+	canvasHTML := "<h1>Plan</h1><p>" + first + "</p><p>" + second + "</p>"
+
+	mock.addHandler("/files.info", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"file": map[string]interface{}{
+				"id":                   "F123CANVAS",
+				"title":                "Plan",
+				"filetype":             "quip",
+				"url_private_download": mock.server.URL + "/files/F123CANVAS/download",
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+	mock.addHandler("/files/F123CANVAS/download", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(canvasHTML))
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	output, err := client.ReadCanvas(context.Background(), ReadCanvasInput{FileID: "F123CANVAS", MaxChunkBytes: 60})
+	if err != nil {
+		t.Fatalf("ReadCanvas failed: %v", err)
+	}
+	if len(output.Files) != 2 {
+		t.Fatalf("Files: got %d, want 2", len(output.Files))
+	}
+	if output.File != output.Files[0] {
+		t.Errorf("File: got %+v, want first chunk %+v", output.File, output.Files[0])
+	}
+
+	want := []string{
+		"# Plan\n\n" + first,
+		"# Plan (continued)\n\n" + second,
+	}
+	for i, ref := range output.Files {
+		data, err := os.ReadFile(ref.Path)
+		if err != nil {
+			t.Fatalf("Failed to read chunk %d: %v", i, err)
+		}
+		if string(data) != want[i] {
+			t.Errorf("chunk %d: got %q, want %q", i, data, want[i])
+		}
+	}
+}
+
+func TestChunkCanvasText_RespectsMaxBytes(t *testing.T) {
+	const maxBytes = 60
+	oversized := strings.Repeat("z", 90)
+	paras := []string{
+		"# A fairly long section heading",
+		strings.Repeat("a", 25),
+		strings.Repeat("b", 25),
+		strings.Repeat("c", 40),
+		strings.Repeat("d", 55),
+		oversized,
+		"## Short",
+		strings.Repeat("e", 30),
+		strings.Repeat("f", 30),
+	}
+
+	chunks := chunkCanvasText(strings.Join(paras, "\n\n"), maxBytes)
+	if len(chunks) < 2 {
+		t.Fatalf("chunks: got %d, want several", len(chunks))
+	}
+	for i, chunk := range chunks {
+		if len(chunk) > maxBytes && chunk != oversized {
+			t.Errorf("chunk %d: got %d bytes, want at most %d: %q", i, len(chunk), maxBytes, chunk)
+		}
+	}
+	if got := chunks[len(chunks)-1]; got != "## Short (continued)\n\n"+strings.Repeat("f", 30) {
+		t.Errorf("last chunk: got %q, want the continued heading before the final paragraph", got)
+	}
+}
+
 func TestReadCanvas_ByChannel(t *testing.T) {
 	mock := newMockSlackServer()
 	defer mock.close()
@@ -153,6 +281,82 @@ func TestReadCanvas_ByChannel(t *testing.T) {
 	}
 }
 
+func TestReadCanvas_ByChannelCachesChannelInfo(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	infoCalls := 0
+	mock.addHandler("/conversations.info", func(w http.ResponseWriter, r *http.Request) {
+		infoCalls++
+		response := map[string]interface{}{
+			"ok": true,
+			"channel": map[string]interface{}{
+				"id":   "C123456789",
+				"name": "design-docs",
+				"properties": map[string]interface{}{
+					"canvas": map[string]interface{}{"file_id": "F456CANVAS"},
+				},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/files.info", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"file": map[string]interface{}{
+				"id":                   "F456CANVAS",
+				"title":                "Channel Canvas",
+				"filetype":             "quip",
+				"url_private_download": mock.server.URL + "/files/F456CANVAS/download",
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/files/F456CANVAS/download", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<p>Channel canvas content</p>"))
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+	client.infoCache = newChannelInfoCache(time.Minute)
+
+	for i := range 2 {
+		if _, err := client.ReadCanvas(context.Background(), ReadCanvasInput{Channel: "C123456789"}); err != nil {
+			t.Fatalf("ReadCanvas call %d failed: %v", i+1, err)
+		}
+	}
+
+	if infoCalls != 1 {
+		t.Errorf("conversations.info calls: got %d, want 1", infoCalls)
+	}
+}
+
+func TestChannelInfoCache_Expires(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	cache := newChannelInfoCache(time.Minute)
+	cache.now = func() time.Time { return now }
+
+	cache.Put(fakeChannel(1))
+	if _, ok := cache.Get(fakeChannel(1).ID); !ok {
+		t.Fatal("Get before expiry: got miss, want hit")
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, ok := cache.Get(fakeChannel(1).ID); ok {
+		t.Error("Get after expiry: got hit, want miss")
+	}
+
+	var disabled *channelInfoCache
+	disabled.Put(fakeChannel(1))
+	if _, ok := disabled.Get(fakeChannel(1).ID); ok {
+		t.Error("nil cache: got hit, want miss")
+	}
+}
+
 func TestReadCanvas_ChannelWithoutCanvas(t *testing.T) {
 	mock := newMockSlackServer()
 	defer mock.close()
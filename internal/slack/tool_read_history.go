@@ -3,28 +3,55 @@ package slack
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/slack-go/slack"
 )
 
 // ReadHistoryInput defines input for reading channel history
 type ReadHistoryInput struct {
-	Channel string `json:"channel" jsonschema:"Channel ID or name (e.g., C1234567890 or #general)"`
+	Channel string `json:"channel" jsonschema:"Channel ID, name or message permalink (e.g., C1234567890, #general or a https://example.slack.com/archives/... link)"`
 	Limit   int    `json:"limit,omitempty" jsonschema:"Number of messages to fetch (default 20, max 100)"`
-	Latest  string `json:"latest,omitempty" jsonschema:"End of time range (Unix timestamp)"`
-	Oldest  string `json:"oldest,omitempty" jsonschema:"Start of time range (Unix timestamp)"`
+	Latest  string `json:"latest,omitempty" jsonschema:"End of time range: Unix timestamp, RFC3339 time, YYYY-MM-DD date, or how long ago (e.g., 1d)"`
+	Oldest  string `json:"oldest,omitempty" jsonschema:"Start of time range: Unix timestamp, RFC3339 time, YYYY-MM-DD date, or how long ago (e.g., 7d, 24h)"`
+
+	IncludeShared bool `json:"include_shared,omitempty" jsonschema:"Include the content of shared/forwarded messages"`
+	ExpandThreads bool `json:"expand_threads,omitempty" jsonschema:"Inline thread replies under each threaded message (at most 20 threads per call)"`
+	MaxReplies    int  `json:"max_replies,omitempty" jsonschema:"Replies to inline per thread when expand_threads is set (default 10, max 100)"`
+	ExcludeBots   bool `json:"exclude_bots,omitempty" jsonschema:"Leave out messages posted by bots and apps (included by default)"`
+
+	WorkspaceSelector
+}
+
+// maxExpandedThreads bounds how many threads a single ReadHistory call expands
+const maxExpandedThreads = 20
+
+// HistoryMessage is a channel message with, when expand_threads is set, its
+// inlined thread replies. Replies lives here rather than on MessageInfo because
+// the tool schemas cannot describe a recursive type.
+type HistoryMessage struct {
+	MessageInfo
+	Replies []MessageInfo `json:"replies,omitempty"`
 }
 
 // ReadHistoryOutput contains channel messages
 type ReadHistoryOutput struct {
-	ChannelID string        `json:"channel_id"`
-	Messages  []MessageInfo `json:"messages"`
-	HasMore   bool          `json:"has_more"`
+	ChannelID string           `json:"channel_id"`
+	Messages  []HistoryMessage `json:"messages"`
+	HasMore   bool             `json:"has_more"`
+
+	// UnexpandedThreads counts threaded messages skipped once maxExpandedThreads was reached
+	UnexpandedThreads int `json:"unexpanded_threads,omitempty"`
 }
 
 // ReadHistory reads message history from a channel
 func (c *Service) ReadHistory(ctx context.Context, input ReadHistoryInput) (ReadHistoryOutput, error) {
-	channelID, err := c.GetChannelID(input.Channel)
+	channelID, err := c.GetChannelID(ctx, input.Channel, messageChannelTypes...)
+	if err != nil {
+		return ReadHistoryOutput{}, err
+	}
+
+	oldest, latest, err := parseTimeRange(input.Oldest, input.Latest, time.Now().In(c.displayLocation()))
 	if err != nil {
 		return ReadHistoryOutput{}, err
 	}
@@ -37,8 +64,8 @@ func (c *Service) ReadHistory(ctx context.Context, input ReadHistoryInput) (Read
 	params := &slack.GetConversationHistoryParameters{
 		ChannelID: channelID,
 		Limit:     limit,
-		Latest:    input.Latest,
-		Oldest:    input.Oldest,
+		Latest:    latest,
+		Oldest:    oldest,
 	}
 
 	history, err := c.api.GetConversationHistoryContext(ctx, params)
@@ -48,23 +75,76 @@ func (c *Service) ReadHistory(ctx context.Context, input ReadHistoryInput) (Read
 
 	output := ReadHistoryOutput{
 		ChannelID: channelID,
-		Messages:  make([]MessageInfo, 0, len(history.Messages)),
+		Messages:  make([]HistoryMessage, 0, len(history.Messages)),
 		HasMore:   history.HasMore,
 	}
 
 	names := c.newUserNameCache(ctx)
+	userIDs := make([]string, 0, len(history.Messages))
+	for _, msg := range history.Messages {
+		userIDs = append(userIDs, msg.User)
+	}
+	names.Prefetch(userIDs)
+
+	maxReplies := 10
+	if input.MaxReplies > 0 && input.MaxReplies <= 100 {
+		maxReplies = input.MaxReplies
+	}
+	expanded := 0
 
 	for _, msg := range history.Messages {
-		output.Messages = append(output.Messages, MessageInfo{
-			Timestamp:        msg.Timestamp,
-			TimestampDisplay: formatSlackTimestamp(msg.Timestamp),
-			User:             msg.User,
-			UserName:         names.Get(msg.User),
-			Text:             msg.Text,
-			ThreadTimestamp:  msg.ThreadTimestamp,
-			ReplyCount:       msg.ReplyCount,
-		})
+		if input.ExcludeBots && isBotMessage(msg) {
+			continue
+		}
+		info := buildMessageInfo(msg, msg.ThreadTimestamp, names.Author(msg), c.displayLocation())
+		if input.IncludeShared {
+			info.Shared = extractSharedMessage(msg)
+		}
+		hm := HistoryMessage{MessageInfo: info}
+		if input.ExpandThreads && msg.ReplyCount > 0 {
+			if expanded < maxExpandedThreads {
+				replies, err := c.threadReplies(ctx, channelID, msg.Timestamp, maxReplies, names, input.IncludeShared, input.ExcludeBots)
+				if err != nil {
+					return ReadHistoryOutput{}, err
+				}
+				hm.Replies = replies
+				expanded++
+			} else {
+				output.UnexpandedThreads++
+			}
+		}
+		output.Messages = append(output.Messages, hm)
 	}
 
 	return output, nil
 }
+
+// threadReplies fetches up to limit replies to the thread rooted at parentTs,
+// excluding the parent message itself and, if excludeBots is set, bot posts
+func (c *Service) threadReplies(ctx context.Context, channelID, parentTs string, limit int, names *userNameCache, includeShared, excludeBots bool) ([]MessageInfo, error) {
+	// The parent is returned first, so ask for one extra message
+	messages, _, _, err := c.api.GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+		ChannelID: channelID,
+		Timestamp: parentTs,
+		Limit:     limit + 1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get thread replies: %w", err)
+	}
+
+	replies := make([]MessageInfo, 0, len(messages))
+	for _, msg := range messages {
+		if msg.Timestamp == parentTs || (excludeBots && isBotMessage(msg)) {
+			continue
+		}
+		if len(replies) == limit {
+			break
+		}
+		info := buildMessageInfo(msg, msg.ThreadTimestamp, names.Author(msg), c.displayLocation())
+		if includeShared {
+			info.Shared = extractSharedMessage(msg)
+		}
+		replies = append(replies, info)
+	}
+	return replies, nil
+}
@@ -1,6 +1,7 @@
 package slack
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 
@@ -9,10 +10,14 @@ import (
 
 // ReadThreadInput defines input for reading thread replies
 type ReadThreadInput struct {
-	Channel   string `json:"channel" jsonschema:"Channel ID (e.g., C1234567890)"`
-	Timestamp string `json:"timestamp" jsonschema:"Thread parent message timestamp (e.g., 1234567890.123456)"`
+	Channel   string `json:"channel" jsonschema:"Channel ID, name or message permalink (e.g., C1234567890 or https://example.slack.com/archives/C1234567890/p1234567890123456)"`
+	Timestamp string `json:"timestamp,omitempty" jsonschema:"Thread parent message timestamp (e.g., 1234567890.123456). Optional when channel is a permalink to the parent or to a reply"`
 	Limit     int    `json:"limit,omitempty" jsonschema:"Number of replies to fetch (default 100, max 1000)"`
 	Cursor    string `json:"cursor,omitempty" jsonschema:"Pagination cursor for fetching more replies"`
+
+	IncludeShared bool `json:"include_shared,omitempty" jsonschema:"Include the content of shared/forwarded messages"`
+
+	WorkspaceSelector
 }
 
 // ReadThreadOutput contains thread replies
@@ -22,11 +27,21 @@ type ReadThreadOutput struct {
 	Messages        []MessageInfo `json:"messages"`
 	HasMore         bool          `json:"has_more"`
 	NextCursor      string        `json:"next_cursor,omitempty"`
+
+	// TotalReactions and Participants summarize the messages on this page
+	TotalReactions int `json:"total_reactions"`
+	Participants   int `json:"participants"`
 }
 
 // ReadThread reads all replies in a thread
 func (c *Service) ReadThread(ctx context.Context, input ReadThreadInput) (ReadThreadOutput, error) {
-	channelID, err := c.GetChannelID(input.Channel)
+	if input.Timestamp == "" {
+		// A reply's permalink names its parent in thread_ts
+		ts, threadTS := permalinkTimestamps(input.Channel)
+		input.Timestamp = cmp.Or(threadTS, ts)
+	}
+
+	channelID, err := c.GetChannelID(ctx, input.Channel, messageChannelTypes...)
 	if err != nil {
 		return ReadThreadOutput{}, err
 	}
@@ -57,18 +72,23 @@ func (c *Service) ReadThread(ctx context.Context, input ReadThreadInput) (ReadTh
 	}
 
 	names := c.newUserNameCache(ctx)
+	participants := make(map[string]bool)
 
 	for _, msg := range messages {
-		output.Messages = append(output.Messages, MessageInfo{
-			Timestamp:        msg.Timestamp,
-			TimestampDisplay: formatSlackTimestamp(msg.Timestamp),
-			User:             msg.User,
-			UserName:         names.Get(msg.User),
-			Text:             msg.Text,
-			ThreadTimestamp:  msg.ThreadTimestamp,
-			ReplyCount:       msg.ReplyCount,
-		})
+		info := buildMessageInfo(msg, msg.ThreadTimestamp, names.Author(msg), c.displayLocation())
+		if input.IncludeShared {
+			info.Shared = extractSharedMessage(msg)
+		}
+		output.Messages = append(output.Messages, info)
+
+		for _, r := range info.Reactions {
+			output.TotalReactions += r.Count
+		}
+		if msg.User != "" {
+			participants[msg.User] = true
+		}
 	}
+	output.Participants = len(participants)
 
 	return output, nil
 }
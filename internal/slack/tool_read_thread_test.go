@@ -36,6 +36,9 @@ func TestReadThread(t *testing.T) {
 					"text":      "Thread parent message",
 					"ts":        "1234567890.123456",
 					"thread_ts": "1234567890.123456",
+					"reactions": []map[string]interface{}{
+						{"name": "thumbsup", "count": 2, "users": []string{"U987654321", "U111111111"}},
+					},
 				},
 				{
 					"type":      "message",
@@ -50,6 +53,9 @@ func TestReadThread(t *testing.T) {
 					"text":      "Second reply",
 					"ts":        "1234567892.123456",
 					"thread_ts": "1234567890.123456",
+					"reactions": []map[string]interface{}{
+						{"name": "eyes", "count": 1, "users": []string{"U987654321"}},
+					},
 				},
 			},
 			"has_more": false,
@@ -132,4 +138,65 @@ func TestReadThread(t *testing.T) {
 	if got := output.HasMore; got != wantHasMore {
 		t.Errorf("HasMore: got %v, want %v", got, wantHasMore)
 	}
+
+	wantTotalReactions := 3
+	if got := output.TotalReactions; got != wantTotalReactions {
+		t.Errorf("TotalReactions: got %d, want %d", got, wantTotalReactions)
+	}
+
+	wantParticipants := 2
+	if got := output.Participants; got != wantParticipants {
+		t.Errorf("Participants: got %d, want %d", got, wantParticipants)
+	}
+
+	wantSummary := "👍2"
+	if got := output.Messages[0].ReactionSummary; got != wantSummary {
+		t.Errorf("Messages[0].ReactionSummary: got %q, want %q", got, wantSummary)
+	}
+}
+
+func TestReadThread_Permalink(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	var gotChannel, gotTS string
+	mock.addHandler("/conversations.replies", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		gotChannel = r.FormValue("channel")
+		gotTS = r.FormValue("ts")
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"type": "message", "text": "Parent", "ts": "1700000000.000100", "thread_ts": "1700000000.000100"},
+				{"type": "message", "text": "Reply", "ts": "1700000050.000200", "thread_ts": "1700000000.000100"},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	tests := []struct {
+		name      string
+		permalink string
+	}{
+		{"parent", "https://example.slack.com/archives/C123456789/p1700000000000100"},
+		{"reply", "https://example.slack.com/archives/C123456789/p1700000050000200?thread_ts=1700000000.000100&cid=C123456789"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := client.ReadThread(context.Background(), ReadThreadInput{Channel: tt.permalink})
+			if err != nil {
+				t.Fatalf("ReadThread failed: %v", err)
+			}
+			if gotChannel != "C123456789" || gotTS != "1700000000.000100" {
+				t.Errorf("request: got channel %q ts %q, want C123456789 1700000000.000100", gotChannel, gotTS)
+			}
+			if len(output.Messages) != 2 {
+				t.Errorf("Messages: got %d, want 2", len(output.Messages))
+			}
+		})
+	}
 }
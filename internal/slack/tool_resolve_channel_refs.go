@@ -0,0 +1,68 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+// reChannelRef matches Slack channel mentions (<#C123|name> or <#C123>) and bare #name references
+var reChannelRef = regexp.MustCompile(`<#([A-Z0-9]+)(?:\|([^>]*))?>|\B#([a-z0-9][a-z0-9_-]*)`)
+
+// ResolveChannelRefsInput defines input for resolving channel references in text
+type ResolveChannelRefsInput struct {
+	Text string `json:"text" jsonschema:"Text containing channel mentions (<#C123|name>) or bare #channel-name references"`
+
+	WorkspaceSelector
+}
+
+// ChannelRef describes a single resolved channel reference
+type ChannelRef struct {
+	Token     string `json:"token"`
+	ChannelID string `json:"channel_id"`
+	Name      string `json:"name,omitempty"`
+}
+
+// ResolveChannelRefsOutput contains the rewritten text and resolved references
+type ResolveChannelRefsOutput struct {
+	Text       string       `json:"text"`
+	Refs       []ChannelRef `json:"refs"`
+	Unresolved []string     `json:"unresolved,omitempty"`
+}
+
+// ResolveChannelRefs finds channel references in text and resolves them to IDs and names.
+// Resolved references are rewritten in canonical <#ID|name> form; unresolved ones are left as-is.
+func (c *Service) ResolveChannelRefs(ctx context.Context, input ResolveChannelRefsInput) (ResolveChannelRefsOutput, error) {
+	if input.Text == "" {
+		return ResolveChannelRefsOutput{}, fmt.Errorf("text is required")
+	}
+
+	output := ResolveChannelRefsOutput{Refs: []ChannelRef{}}
+
+	output.Text = reChannelRef.ReplaceAllStringFunc(input.Text, func(token string) string {
+		m := reChannelRef.FindStringSubmatch(token)
+
+		var ref ChannelRef
+		if m[1] != "" {
+			ref = ChannelRef{Token: token, ChannelID: m[1], Name: m[2]}
+			if ref.Name == "" {
+				ref.Name = c.channelName(ctx, ref.ChannelID)
+			}
+		} else {
+			id, err := c.GetChannelID(ctx, m[3])
+			if err != nil {
+				output.Unresolved = append(output.Unresolved, token)
+				return token
+			}
+			ref = ChannelRef{Token: token, ChannelID: id, Name: m[3]}
+		}
+
+		output.Refs = append(output.Refs, ref)
+		if ref.Name == "" {
+			return fmt.Sprintf("<#%s>", ref.ChannelID)
+		}
+		return fmt.Sprintf("<#%s|%s>", ref.ChannelID, ref.Name)
+	})
+
+	return output, nil
+}
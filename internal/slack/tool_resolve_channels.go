@@ -0,0 +1,76 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// ResolveChannelsInput defines input for resolving several channel names at once
+type ResolveChannelsInput struct {
+	Names []string `json:"names" jsonschema:"Channel names to resolve (with or without #). Channel IDs are passed through unchanged"`
+
+	WorkspaceSelector
+}
+
+// ResolveChannelsOutput maps each resolved name to its channel ID
+type ResolveChannelsOutput struct {
+	Channels map[string]string `json:"channels"`
+	NotFound []string          `json:"not_found,omitempty"`
+}
+
+// ResolveChannels resolves many channel names to IDs. Names missing from the
+// index are looked up in a single pass over the channel directory, which
+// stops early once every name is found, rather than one scan per name. Like
+// GetChannelID, the pass reads at most maxChannelPages pages; names it doesn't
+// reach are reported as not found.
+func (c *Service) ResolveChannels(ctx context.Context, input ResolveChannelsInput) (ResolveChannelsOutput, error) {
+	if len(input.Names) == 0 {
+		return ResolveChannelsOutput{}, fmt.Errorf("names is required")
+	}
+
+	output := ResolveChannelsOutput{Channels: make(map[string]string, len(input.Names))}
+	missing := map[string]bool{}
+	for _, name := range input.Names {
+		if isChannelID(name) {
+			output.Channels[name] = name
+		} else if ch, ok := c.index.GetByName(strings.TrimPrefix(name, "#")); ok {
+			output.Channels[name] = ch.ID
+		} else {
+			missing[name] = true
+		}
+	}
+
+	cursor := ""
+	for page := 0; len(missing) > 0 && page < c.maxChannelPages; page++ {
+		_, next, err := c.listConversations(ctx, &slack.GetConversationsParameters{
+			Cursor: cursor,
+			Types:  defaultChannelTypes,
+			Limit:  1000,
+		})
+		if err != nil {
+			return ResolveChannelsOutput{}, fmt.Errorf("failed to list channels: %w", err)
+		}
+
+		for name := range missing {
+			if ch, ok := c.index.GetByName(strings.TrimPrefix(name, "#")); ok {
+				output.Channels[name] = ch.ID
+				delete(missing, name)
+			}
+		}
+
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	for _, name := range input.Names {
+		if missing[name] {
+			output.NotFound = append(output.NotFound, name)
+		}
+	}
+	return output, nil
+}
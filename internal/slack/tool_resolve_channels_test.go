@@ -0,0 +1,124 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestResolveChannels(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	calls := 0
+	mock.addHandler("/conversations.list", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		calls++
+
+		var response map[string]interface{}
+		if r.FormValue("cursor") == "" {
+			response = map[string]interface{}{
+				"ok": true,
+				"channels": []map[string]interface{}{
+					{"id": "C000000001", "name": "general", "name_normalized": "general"},
+					{"id": "C000000002", "name": "random", "name_normalized": "random"},
+				},
+				"response_metadata": map[string]string{"next_cursor": "page2"},
+			}
+		} else {
+			response = map[string]interface{}{
+				"ok": true,
+				"channels": []map[string]interface{}{
+					{"id": "C000000003", "name": "engineering", "name_normalized": "engineering"},
+				},
+				"response_metadata": map[string]string{"next_cursor": ""},
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+	client.maxChannelPages = 5
+
+	output, err := client.ResolveChannels(context.Background(), ResolveChannelsInput{
+		Names: []string{"#general", "engineering", "missing"},
+	})
+	if err != nil {
+		t.Fatalf("ResolveChannels failed: %v", err)
+	}
+
+	want := map[string]string{"#general": "C000000001", "engineering": "C000000003"}
+	if len(output.Channels) != len(want) {
+		t.Errorf("Channels: got %v, want %v", output.Channels, want)
+	}
+	for name, id := range want {
+		if output.Channels[name] != id {
+			t.Errorf("Channels[%q]: got %q, want %q", name, output.Channels[name], id)
+		}
+	}
+	if !slices.Equal(output.NotFound, []string{"missing"}) {
+		t.Errorf("NotFound: got %v, want [missing]", output.NotFound)
+	}
+	if calls != 2 {
+		t.Errorf("conversations.list calls: got %d, want 2 (one directory pass)", calls)
+	}
+
+	t.Run("page limit", func(t *testing.T) {
+		calls = 0
+		client, _, responsesDir := newTestClient(t, mock)
+		defer os.RemoveAll(responsesDir)
+		client.maxChannelPages = 1
+
+		output, err := client.ResolveChannels(context.Background(), ResolveChannelsInput{
+			Names: []string{"general", "engineering"},
+		})
+		if err != nil {
+			t.Fatalf("ResolveChannels failed: %v", err)
+		}
+		if output.Channels["general"] != "C000000001" {
+			t.Errorf("Channels[general]: got %q, want C000000001", output.Channels["general"])
+		}
+		if !slices.Equal(output.NotFound, []string{"engineering"}) {
+			t.Errorf("NotFound: got %v, want [engineering]", output.NotFound)
+		}
+		if calls != 1 {
+			t.Errorf("conversations.list calls: got %d, want 1", calls)
+		}
+	})
+
+	t.Run("index only", func(t *testing.T) {
+		calls = 0
+		client, _, responsesDir := newTestClient(t, mock)
+		defer os.RemoveAll(responsesDir)
+
+		output, err := client.ResolveChannels(context.Background(), ResolveChannelsInput{
+			Names: []string{"general", "C000000009"},
+		})
+		if err != nil {
+			t.Fatalf("ResolveChannels failed: %v", err)
+		}
+		if output.Channels["C000000009"] != "C000000009" || !slices.Equal(output.NotFound, []string{"general"}) {
+			t.Errorf("got channels %v, not found %v; want the ID passed through and general not found", output.Channels, output.NotFound)
+		}
+		if calls != 0 {
+			t.Errorf("conversations.list calls: got %d, want 0 with SLACK_MAX_CHANNEL_PAGES unset", calls)
+		}
+	})
+}
+
+func TestResolveChannels_RequiresNames(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	if _, err := client.ResolveChannels(context.Background(), ResolveChannelsInput{}); err == nil {
+		t.Error("expected error for empty names")
+	}
+}
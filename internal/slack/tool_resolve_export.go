@@ -0,0 +1,108 @@
+package slack
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// ResolveExportInput defines input for resolving names in an existing export
+type ResolveExportInput struct {
+	Path string `json:"path" jsonschema:"Path or file name of a JSON-lines export in the responses directory"`
+
+	WorkspaceSelector
+}
+
+// ResolveExportOutput references the resolved copy of the export
+type ResolveExportOutput struct {
+	File             FileRef `json:"file"`
+	MessageCount     int     `json:"message_count"`
+	UserNamesFilled  int     `json:"user_names_filled"`
+	MentionsRendered int     `json:"mentions_rendered"`
+}
+
+// ResolveExport rewrites a JSON-lines export with user names filled in and
+// <@U...>/<#C...> mentions rendered as @name/#name. The original file is left
+// untouched; the result is written to a new file.
+func (c *Service) ResolveExport(ctx context.Context, input ResolveExportInput) (ResolveExportOutput, error) {
+	name, err := c.responseName(input.Path)
+	if err != nil {
+		return ResolveExportOutput{}, err
+	}
+
+	src, err := c.responses.Open(name)
+	if err != nil {
+		return ResolveExportOutput{}, fmt.Errorf("failed to read export: %w", err)
+	}
+	defer src.Close()
+
+	names := c.newUserNameCache(ctx)
+	channelName := func(id string) string { return c.channelName(ctx, id) }
+
+	var output ResolveExportOutput
+	base := strings.TrimSuffix(name, filepath.Ext(name))
+	ref, err := c.responses.WriteJSONLines(base+"-resolved", func(jw JSONLineWriter) error {
+		scanner := bufio.NewScanner(src)
+		scanner.Buffer(make([]byte, 1024*1024), 10*1024*1024)
+		for scanner.Scan() {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+				continue
+			}
+
+			var msg MessageInfo
+			if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+				return fmt.Errorf("failed to parse line %d: %w", output.MessageCount+1, err)
+			}
+
+			if msg.UserName == "" && msg.User != "" {
+				if msg.UserName = names.Get(msg.User); msg.UserName != "" {
+					output.UserNamesFilled++
+				}
+			}
+			output.MentionsRendered += len(reMention.FindAllStringIndex(msg.Text, -1))
+			msg.Text = renderMentions(msg.Text, names.Get, channelName)
+
+			if err := jw.WriteLine(msg); err != nil {
+				return err
+			}
+			output.MessageCount++
+		}
+		if err := scanner.Err(); err != nil {
+			return fmt.Errorf("failed to read export: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return ResolveExportOutput{}, err
+	}
+
+	output.File = ref
+	return output, nil
+}
+
+// responseName maps a response file name, or a path to a file directly
+// inside the responses directory, to its name. Anything else is rejected.
+func (c *Service) responseName(path string) (string, error) {
+	if path == "" {
+		return "", fmt.Errorf("path is required")
+	}
+	if !filepath.IsAbs(path) {
+		if path != filepath.Base(path) {
+			return "", fmt.Errorf("path %q must be a file name or an absolute path in the responses directory", path)
+		}
+		return path, nil
+	}
+
+	rel, err := filepath.Rel(c.responses.Dir(), filepath.Clean(path))
+	if err != nil || rel != filepath.Base(rel) || rel == ".." || rel == "." {
+		return "", fmt.Errorf("path %q is outside the responses directory", path)
+	}
+	return rel, nil
+}
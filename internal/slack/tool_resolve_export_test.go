@@ -0,0 +1,102 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveExport(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	mock.addHandler("/users.info", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		names := map[string]string{"U111": "alice", "U222": "bob"}
+		userID := r.FormValue("user")
+		response := map[string]interface{}{
+			"ok":   true,
+			"user": map[string]interface{}{"id": userID, "name": names[userID]},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/conversations.info", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok":      true,
+			"channel": map[string]interface{}{"id": "C333333333", "name": "random"},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	fixture := strings.Join([]string{
+		`{"timestamp":"1704067200.000001","raw_timestamp":"1704067200.000001","user":"U111","text":"ping <@U222> in <#C333333333>"}`,
+		`{"timestamp":"1704067300.000001","raw_timestamp":"1704067300.000001","user":"U222","user_name":"bob","text":"thanks <@U111|alice>"}`,
+	}, "\n") + "\n"
+	fixturePath := filepath.Join(responsesDir, "export-C123456789-1.jsonl")
+	if err := os.WriteFile(fixturePath, []byte(fixture), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+
+	output, err := client.ResolveExport(context.Background(), ResolveExportInput{Path: fixturePath})
+	if err != nil {
+		t.Fatalf("ResolveExport failed: %v", err)
+	}
+
+	if output.MessageCount != 2 || output.UserNamesFilled != 1 || output.MentionsRendered != 3 {
+		t.Errorf("counts: got messages=%d names=%d mentions=%d, want 2, 1, 3",
+			output.MessageCount, output.UserNamesFilled, output.MentionsRendered)
+	}
+	if !strings.HasPrefix(output.File.Name, "export-C123456789-1-resolved-") {
+		t.Errorf("File.Name: got %q, want export-C123456789-1-resolved-*", output.File.Name)
+	}
+
+	content, err := os.ReadFile(output.File.Path)
+	if err != nil {
+		t.Fatalf("failed to read resolved export: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	want := []struct{ userName, text string }{
+		{"alice", "ping @bob in #random"},
+		{"bob", "thanks @alice"},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("lines: got %d, want %d", len(lines), len(want))
+	}
+	for i, line := range lines {
+		var msg MessageInfo
+		if err := json.Unmarshal([]byte(line), &msg); err != nil {
+			t.Fatalf("failed to parse line %d: %v", i, err)
+		}
+		if msg.UserName != want[i].userName || msg.Text != want[i].text {
+			t.Errorf("line %d: got (%q, %q), want (%q, %q)", i, msg.UserName, msg.Text, want[i].userName, want[i].text)
+		}
+		if msg.RawTimestamp == "" {
+			t.Errorf("line %d: raw_timestamp was dropped", i)
+		}
+	}
+
+	original, _ := os.ReadFile(fixturePath)
+	if string(original) != fixture {
+		t.Error("original export was modified")
+	}
+}
+
+func TestResolveExport_RejectsPathsOutsideResponses(t *testing.T) {
+	client := newServiceWithIndex(nil, nil, nil, NewFileResponseWriter(t.TempDir()))
+
+	for _, path := range []string{"", "../secret.jsonl", "sub/export.jsonl", "/etc/passwd"} {
+		if _, err := client.ResolveExport(context.Background(), ResolveExportInput{Path: path}); err == nil {
+			t.Errorf("ResolveExport(%q): expected error", path)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ResolvePermalinkInput defines input for converting a permalink to channel and timestamp
+type ResolvePermalinkInput struct {
+	Permalink string `json:"permalink" jsonschema:"Slack message permalink (e.g., https://example.slack.com/archives/C1234567890/p1700000000123456)"`
+
+	WorkspaceSelector
+}
+
+// ResolvePermalinkOutput contains the channel and timestamp the permalink points to
+type ResolvePermalinkOutput struct {
+	Channel         string `json:"channel"`
+	Timestamp       string `json:"timestamp"`
+	ThreadTimestamp string `json:"thread_ts,omitempty"`
+}
+
+// ResolvePermalink converts a message permalink into the channel and timestamp
+// other tools accept. For thread replies, ThreadTimestamp is the parent's timestamp.
+func (c *Service) ResolvePermalink(ctx context.Context, input ResolvePermalinkInput) (ResolvePermalinkOutput, error) {
+	channel, ts, err := ParsePermalink(input.Permalink)
+	if err != nil {
+		return ResolvePermalinkOutput{}, err
+	}
+
+	// ParsePermalink has already validated the URL
+	u, _ := url.Parse(input.Permalink)
+	return ResolvePermalinkOutput{
+		Channel:         channel,
+		Timestamp:       ts,
+		ThreadTimestamp: u.Query().Get("thread_ts"),
+	}, nil
+}
+
+// ParsePermalink extracts the channel ID and message timestamp from a Slack
+// permalink such as https://example.slack.com/archives/C123/p1700000000123456.
+// The compact p-style timestamp is returned in sec.micro form (1700000000.123456).
+func ParsePermalink(permalink string) (channel, ts string, err error) {
+	u, err := url.Parse(permalink)
+	if err != nil || u.Host == "" {
+		return "", "", fmt.Errorf("invalid permalink %q", permalink)
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) != 3 || parts[0] != "archives" {
+		return "", "", fmt.Errorf("invalid permalink %q: expected /archives/<channel>/p<timestamp>", permalink)
+	}
+	channel = parts[1]
+	if !isChannelID(channel) {
+		return "", "", fmt.Errorf("invalid permalink %q: %q is not a channel ID", permalink, channel)
+	}
+
+	digits, ok := strings.CutPrefix(parts[2], "p")
+	if !ok || len(digits) <= 6 || strings.Trim(digits, "0123456789") != "" {
+		return "", "", fmt.Errorf("invalid permalink %q: malformed message timestamp %q", permalink, parts[2])
+	}
+	return channel, digits[:len(digits)-6] + "." + digits[len(digits)-6:], nil
+}
+
+// isPermalink reports whether s looks like a URL rather than a channel name
+func isPermalink(s string) bool {
+	return strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "http://")
+}
+
+// permalinkTimestamps returns the message and thread timestamps of a
+// permalink given in place of a channel, or empty strings if channel is not
+// a permalink. Errors are left for GetChannelID to report.
+func permalinkTimestamps(channel string) (ts, threadTS string) {
+	if !isPermalink(channel) {
+		return "", ""
+	}
+	_, ts, err := ParsePermalink(channel)
+	if err != nil {
+		return "", ""
+	}
+	u, _ := url.Parse(channel)
+	return ts, u.Query().Get("thread_ts")
+}
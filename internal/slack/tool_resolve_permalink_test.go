@@ -0,0 +1,81 @@
+package slack
+
+import (
+	"context"
+	"testing"
+)
+
+func TestParsePermalink(t *testing.T) {
+	tests := []struct {
+		name        string
+		permalink   string
+		wantChannel string
+		wantTs      string
+		wantErr     bool
+	}{
+		{
+			name:        "message",
+			permalink:   "https://example.slack.com/archives/C1234567890/p1700000000123456",
+			wantChannel: "C1234567890",
+			wantTs:      "1700000000.123456",
+		},
+		{
+			name:        "thread reply",
+			permalink:   "https://example.slack.com/archives/C1234567890/p1700000100654321?thread_ts=1700000000.123456&cid=C1234567890",
+			wantChannel: "C1234567890",
+			wantTs:      "1700000100.654321",
+		},
+		{
+			name:        "trailing slash",
+			permalink:   "https://example.slack.com/archives/G1234567890/p1700000000123456/",
+			wantChannel: "G1234567890",
+			wantTs:      "1700000000.123456",
+		},
+		{name: "empty", permalink: "", wantErr: true},
+		{name: "not a URL", permalink: "C1234567890/p1700000000123456", wantErr: true},
+		{name: "no archives segment", permalink: "https://example.slack.com/messages/C1234567890/p1700000000123456", wantErr: true},
+		{name: "missing timestamp", permalink: "https://example.slack.com/archives/C1234567890", wantErr: true},
+		{name: "timestamp without p", permalink: "https://example.slack.com/archives/C1234567890/1700000000123456", wantErr: true},
+		{name: "non-numeric timestamp", permalink: "https://example.slack.com/archives/C1234567890/p17000000001234ab", wantErr: true},
+		{name: "timestamp too short", permalink: "https://example.slack.com/archives/C1234567890/p123456", wantErr: true},
+		{name: "bad channel", permalink: "https://example.slack.com/archives/general/p1700000000123456", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			channel, ts, err := ParsePermalink(tt.permalink)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParsePermalink(%q): expected error, got (%q, %q)", tt.permalink, channel, ts)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParsePermalink(%q) failed: %v", tt.permalink, err)
+			}
+			if channel != tt.wantChannel || ts != tt.wantTs {
+				t.Errorf("ParsePermalink(%q): got (%q, %q), want (%q, %q)", tt.permalink, channel, ts, tt.wantChannel, tt.wantTs)
+			}
+		})
+	}
+}
+
+func TestResolvePermalink_ThreadTimestamp(t *testing.T) {
+	client := newServiceWithIndex(nil, nil, nil, nil)
+
+	output, err := client.ResolvePermalink(context.Background(), ResolvePermalinkInput{
+		Permalink: "https://example.slack.com/archives/C1234567890/p1700000100654321?thread_ts=1700000000.123456&cid=C1234567890",
+	})
+	if err != nil {
+		t.Fatalf("ResolvePermalink failed: %v", err)
+	}
+
+	want := ResolvePermalinkOutput{
+		Channel:         "C1234567890",
+		Timestamp:       "1700000100.654321",
+		ThreadTimestamp: "1700000000.123456",
+	}
+	if output != want {
+		t.Errorf("got %+v, want %+v", output, want)
+	}
+}
@@ -0,0 +1,39 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+)
+
+// SearchInChannelInput defines input for searching one channel
+type SearchInChannelInput struct {
+	Channel string `json:"channel" jsonschema:"Channel ID or name to search in"`
+	Query   string `json:"query" jsonschema:"Search terms; Slack modifiers other than in: may be included"`
+	Count   int    `json:"count,omitempty" jsonschema:"Number of results to return (default 20, max 100)"`
+
+	WorkspaceSelector
+}
+
+// SearchInChannel searches messages in a single channel. The channel is
+// resolved first, so an unknown name fails instead of silently matching
+// nothing, and the search then runs through SearchMessages.
+func (c *Service) SearchInChannel(ctx context.Context, input SearchInChannelInput) (SearchMessagesOutput, error) {
+	if input.Channel == "" {
+		return SearchMessagesOutput{}, fmt.Errorf("channel is required")
+	}
+	if input.Query == "" {
+		return SearchMessagesOutput{}, fmt.Errorf("query is required")
+	}
+
+	channelID, err := c.GetChannelID(ctx, input.Channel, messageChannelTypes...)
+	if err != nil {
+		return SearchMessagesOutput{}, err
+	}
+
+	return c.SearchMessages(ctx, SearchMessagesInput{
+		Query:             input.Query,
+		Count:             input.Count,
+		InChannel:         channelID,
+		WorkspaceSelector: input.WorkspaceSelector,
+	})
+}
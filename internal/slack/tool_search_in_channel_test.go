@@ -0,0 +1,120 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestSearchInChannel(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	var gotQuery string
+	mock.addHandler("/search.messages", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		gotQuery = r.FormValue("query")
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": map[string]interface{}{
+				"total": 1,
+				"matches": []map[string]interface{}{
+					{"ts": "1704067200.000001", "text": "deploy failed", "user": "U123456789",
+						"channel": map[string]interface{}{"id": "C123456789", "name": "eng-alerts"}},
+				},
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/conversations.info", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok":      true,
+			"channel": map[string]interface{}{"id": "C123456789", "name": "eng-alerts"},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	tests := []struct {
+		name    string
+		channel string
+	}{
+		{"by ID", "C123456789"},
+		{"by name", "#eng-alerts"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _, responsesDir := newTestClient(t, mock)
+			defer os.RemoveAll(responsesDir)
+			client.index.Add([]slack.Channel{{GroupConversation: slack.GroupConversation{
+				Conversation: slack.Conversation{ID: "C123456789", NameNormalized: "eng-alerts"},
+				Name:         "eng-alerts",
+			}}})
+
+			output, err := client.SearchInChannel(context.Background(), SearchInChannelInput{Channel: tt.channel, Query: "deploy"})
+			if err != nil {
+				t.Fatalf("SearchInChannel failed: %v", err)
+			}
+			if want := "in:<#C123456789|eng-alerts> deploy"; gotQuery != want {
+				t.Errorf("query sent: got %q, want %q", gotQuery, want)
+			}
+			if len(output.Matches) != 1 || output.Matches[0].ChannelID != "C123456789" {
+				t.Errorf("Matches: got %+v", output.Matches)
+			}
+		})
+	}
+}
+
+func TestSearchInChannel_DirectMessage(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	var gotQuery string
+	mock.addHandler("/search.messages", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		gotQuery = r.FormValue("query")
+		response := map[string]interface{}{
+			"ok":       true,
+			"messages": map[string]interface{}{"total": 0, "matches": []map[string]interface{}{}},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/conversations.info", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok":      true,
+			"channel": map[string]interface{}{"id": "D123456789", "is_im": true, "user": "U123456789"},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	if _, err := client.SearchInChannel(context.Background(), SearchInChannelInput{Channel: "D123456789", Query: "deploy"}); err != nil {
+		t.Fatalf("SearchInChannel failed: %v", err)
+	}
+	if want := "in:<#D123456789> deploy"; gotQuery != want {
+		t.Errorf("query sent: got %q, want %q", gotQuery, want)
+	}
+}
+
+func TestSearchInChannel_UnknownChannel(t *testing.T) {
+	client := newServiceWithIndex(nil, nil, nil, nil)
+
+	if _, err := client.SearchInChannel(context.Background(), SearchInChannelInput{Channel: "#nowhere", Query: "deploy"}); err == nil {
+		t.Error("SearchInChannel: expected error for unknown channel")
+	}
+	if _, err := client.SearchInChannel(context.Background(), SearchInChannelInput{Channel: "#nowhere"}); err == nil {
+		t.Error("SearchInChannel: expected error for missing query")
+	}
+}
@@ -3,32 +3,45 @@ package slack
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/slack-go/slack"
 )
 
 // SearchMessagesInput defines input for searching messages
 type SearchMessagesInput struct {
-	Query string `json:"query" jsonschema:"Search query (supports Slack search modifiers like from:@user, in:#channel, before:date)"`
+	Query string `json:"query,omitempty" jsonschema:"Search query (supports Slack search modifiers like from:@user, in:#channel, before:date)"`
 	Count int    `json:"count,omitempty" jsonschema:"Number of results to return (default 20, max 100)"`
 	Sort  string `json:"sort,omitempty" jsonschema:"Sort order: score (relevance) or timestamp (recent first)"`
+
+	After     string `json:"after,omitempty" jsonschema:"Only messages after this date (YYYY-MM-DD or Unix timestamp)"`
+	Before    string `json:"before,omitempty" jsonschema:"Only messages before this date (YYYY-MM-DD or Unix timestamp)"`
+	FromUser  string `json:"from_user,omitempty" jsonschema:"Only messages from this user (user ID or name)"`
+	InChannel string `json:"in_channel,omitempty" jsonschema:"Only messages in this channel (channel ID or name)"`
+
+	WorkspaceSelector
 }
 
 // SearchMatch represents a search result
 type SearchMatch struct {
-	Timestamp string `json:"timestamp"`
-	Channel   string `json:"channel"`
-	User      string `json:"user"`
-	UserName  string `json:"user_name,omitempty"`
-	Text      string `json:"text"`
-	Permalink string `json:"permalink"`
+	Timestamp    Timestamp `json:"timestamp"`
+	RawTimestamp string    `json:"raw_timestamp"`
+	Channel      string    `json:"channel"`
+	ChannelID    string    `json:"channel_id"`
+	User         string    `json:"user"`
+	UserName     string    `json:"user_name,omitempty"`
+	Text         string    `json:"text"`
+	Permalink    string    `json:"permalink"`
 }
 
-// SearchMessagesOutput contains search results
+// SearchMessagesOutput contains search results. Large result sets are written
+// to File instead of being returned in Matches.
 type SearchMessagesOutput struct {
 	Query   string        `json:"query"`
 	Total   int           `json:"total"`
-	Matches []SearchMatch `json:"matches"`
+	Matches []SearchMatch `json:"matches,omitempty"`
+	File    *FileRef      `json:"file,omitempty"`
 }
 
 // SearchMessages searches messages across the workspace
@@ -49,27 +62,145 @@ func (c *Service) SearchMessages(ctx context.Context, input SearchMessagesInput)
 		Count:         count,
 	}
 
-	results, err := c.searchMessages(ctx, input.Query, params)
+	query, err := c.composeSearchQuery(ctx, input)
+	if err != nil {
+		return SearchMessagesOutput{}, err
+	}
+
+	results, err := c.searchMessages(ctx, query, params)
 	if err != nil {
 		return SearchMessagesOutput{}, fmt.Errorf("failed to search: %w", err)
 	}
 
 	output := SearchMessagesOutput{
-		Query:   input.Query,
+		Query:   query,
 		Total:   results.Total,
 		Matches: make([]SearchMatch, 0, len(results.Matches)),
 	}
 
+	var unnamed []string
+	for _, match := range results.Matches {
+		if match.Channel.Name == "" {
+			unnamed = append(unnamed, match.Channel.ID)
+		}
+	}
+	channelNames := c.resolveChannelNames(ctx, unnamed)
+
 	for _, match := range results.Matches {
+		channelName := match.Channel.Name
+		if channelName == "" {
+			channelName = channelNames[match.Channel.ID]
+		}
 		output.Matches = append(output.Matches, SearchMatch{
-			Timestamp: match.Timestamp,
-			Channel:   match.Channel.Name,
-			User:      match.User,
-			UserName:  match.Username,
-			Text:      match.Text,
-			Permalink: match.Permalink,
+			Timestamp:    newTimestamp(match.Timestamp, c.displayLocation()),
+			RawTimestamp: match.Timestamp,
+			Channel:      channelName,
+			ChannelID:    match.Channel.ID,
+			User:         match.User,
+			UserName:     match.Username,
+			Text:         match.Text,
+			Permalink:    match.Permalink,
 		})
 	}
 
+	if !c.fitsInline(output.Matches) {
+		ref, err := c.responses.WriteJSON("search", output.Matches)
+		if err != nil {
+			return SearchMessagesOutput{}, fmt.Errorf("failed to write response: %w", err)
+		}
+		output.File = &ref
+		output.Matches = nil
+	}
+
 	return output, nil
 }
+
+// composeSearchQuery builds the Slack query string from the structured search
+// filters, followed by the raw query. A known channel is referenced in Slack's
+// <#ID|name> form, which pins the search to that channel even when another
+// shares its name; one with no name, such as a DM, is referenced as <#ID>.
+// User IDs are resolved to names because Slack's from: modifier matches on names.
+func (c *Service) composeSearchQuery(ctx context.Context, input SearchMessagesInput) (string, error) {
+	var terms []string
+
+	if input.InChannel != "" {
+		name := strings.TrimPrefix(input.InChannel, "#")
+		var id string
+		if isChannelID(name) {
+			id, name = name, c.channelName(ctx, name)
+		} else if ch, ok := c.index.GetByName(name); ok {
+			id = ch.ID
+		}
+
+		switch {
+		case id != "" && name != "":
+			terms = append(terms, "in:<#"+id+"|"+name+">")
+		case id != "":
+			terms = append(terms, "in:<#"+id+">")
+		default:
+			terms = append(terms, "in:#"+name)
+		}
+	}
+
+	if input.FromUser != "" {
+		name := strings.TrimPrefix(input.FromUser, "@")
+		if isUserID(name) {
+			user, err := c.api.GetUserInfoContext(ctx, name)
+			if err != nil {
+				return "", fmt.Errorf("failed to resolve from_user: %w", err)
+			}
+			name = user.Name
+		}
+		terms = append(terms, "from:@"+name)
+	}
+
+	for _, f := range []struct{ modifier, value string }{
+		{"after", input.After},
+		{"before", input.Before},
+	} {
+		if f.value == "" {
+			continue
+		}
+		date, err := searchDate(f.value, c.displayLocation())
+		if err != nil {
+			return "", fmt.Errorf("invalid %s: %w", f.modifier, err)
+		}
+		terms = append(terms, f.modifier+":"+date)
+	}
+
+	if q := strings.TrimSpace(input.Query); q != "" {
+		terms = append(terms, q)
+	}
+	if len(terms) == 0 {
+		return "", fmt.Errorf("query or at least one search filter is required")
+	}
+	return strings.Join(terms, " "), nil
+}
+
+// searchDate normalizes a YYYY-MM-DD date or Unix timestamp to the
+// YYYY-MM-DD form Slack's before: and after: modifiers expect. Timestamps
+// are converted in loc.
+func searchDate(v string, loc *time.Location) (string, error) {
+	if _, err := time.Parse(time.DateOnly, v); err == nil {
+		return v, nil
+	}
+	sec, err := parseUnixSeconds(v)
+	if err != nil {
+		return "", fmt.Errorf("%q is neither YYYY-MM-DD nor a Unix timestamp", v)
+	}
+	return time.Unix(sec, 0).In(loc).Format(time.DateOnly), nil
+}
+
+// isUserID reports whether s looks like a Slack user ID (U or W followed by
+// uppercase alphanumerics)
+func isUserID(s string) bool {
+	if len(s) < 9 || (s[0] != 'U' && s[0] != 'W') {
+		return false
+	}
+	for _, ch := range s {
+		if !((ch >= 'A' && ch <= 'Z') || (ch >= '0' && ch <= '9')) {
+			return false
+		}
+	}
+	return true
+}
@@ -3,9 +3,14 @@ package slack
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"os"
+	"strings"
+	"sync/atomic"
 	"testing"
+
+	"github.com/slack-go/slack"
 )
 
 func TestSearchMessages(t *testing.T) {
@@ -21,7 +26,7 @@ func TestSearchMessages(t *testing.T) {
 				"matches": []map[string]interface{}{
 					{
 						"ts":        "1234567890.123456",
-						"channel":   map[string]interface{}{"name": "general"},
+						"channel":   map[string]interface{}{"id": "C123", "name": "general"},
 						"user":      "U123456789",
 						"username":  "alice",
 						"text":      "Hello world",
@@ -29,7 +34,7 @@ func TestSearchMessages(t *testing.T) {
 					},
 					{
 						"ts":        "1234567891.123456",
-						"channel":   map[string]interface{}{"name": "random"},
+						"channel":   map[string]interface{}{"id": "C456", "name": "random"},
 						"user":      "U987654321",
 						"username":  "bob",
 						"text":      "Hi there",
@@ -86,4 +91,184 @@ func TestSearchMessages(t *testing.T) {
 	if got := output.Matches[0].Channel; got != wantChannel {
 		t.Errorf("Matches[0].Channel: got %q, want %q", got, wantChannel)
 	}
+
+	wantChannelID := "C123"
+	if got := output.Matches[0].ChannelID; got != wantChannelID {
+		t.Errorf("Matches[0].ChannelID: got %q, want %q", got, wantChannelID)
+	}
+
+	wantRawTimestamp := "1234567890.123456"
+	if got := output.Matches[0].RawTimestamp; got != wantRawTimestamp {
+		t.Errorf("Matches[0].RawTimestamp: got %q, want %q", got, wantRawTimestamp)
+	}
+}
+
+func TestSearchMessages_ResolvesEachChannelOnce(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	channelIDs := []string{"C111111111", "C222222222", "C333333333"}
+	matches := make([]map[string]interface{}, 0, 12)
+	for i := 0; i < 12; i++ {
+		matches = append(matches, map[string]interface{}{
+			"ts":      fmt.Sprintf("1234567890.%06d", i),
+			"channel": map[string]interface{}{"id": channelIDs[i%3]},
+			"user":    "U123456789",
+			"text":    fmt.Sprintf("match %d", i),
+		})
+	}
+
+	mock.addHandler("/search.messages", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": map[string]interface{}{
+				"total":   len(matches),
+				"matches": matches,
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	var infoCalls atomic.Int32
+	mock.addHandler("/conversations.info", func(w http.ResponseWriter, r *http.Request) {
+		infoCalls.Add(1)
+		r.ParseForm()
+		channelID := r.FormValue("channel")
+		name := "name-" + strings.ToLower(channelID)
+		response := map[string]interface{}{
+			"ok": true,
+			"channel": map[string]interface{}{
+				"id":              channelID,
+				"name":            name,
+				"name_normalized": name,
+			},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	ctx := context.Background()
+	output, err := client.SearchMessages(ctx, SearchMessagesInput{Query: "match", Count: 100})
+	if err != nil {
+		t.Fatalf("SearchMessages failed: %v", err)
+	}
+
+	if got := infoCalls.Load(); got != 3 {
+		t.Errorf("conversations.info calls: got %d, want 3", got)
+	}
+
+	for i, match := range output.Matches {
+		want := "name-" + strings.ToLower(channelIDs[i%3])
+		if match.Channel != want {
+			t.Errorf("Matches[%d].Channel: got %q, want %q", i, match.Channel, want)
+		}
+	}
+
+	// A second search is served entirely from the channel index
+	if _, err := client.SearchMessages(ctx, SearchMessagesInput{Query: "match", Count: 100}); err != nil {
+		t.Fatalf("second SearchMessages failed: %v", err)
+	}
+	if got := infoCalls.Load(); got != 3 {
+		t.Errorf("conversations.info calls after second search: got %d, want 3", got)
+	}
+}
+
+func TestSearchMessages_ComposesQuery(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	var gotQuery string
+	mock.addHandler("/search.messages", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		gotQuery = r.FormValue("query")
+		response := map[string]interface{}{
+			"ok":       true,
+			"messages": map[string]interface{}{"total": 0, "matches": []map[string]interface{}{}},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/conversations.info", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok":      true,
+			"channel": map[string]interface{}{"id": "C123456789", "name": "general"},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/users.info", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok":   true,
+			"user": map[string]interface{}{"id": "U123456789", "name": "alice"},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+	client.index.Add([]slack.Channel{{GroupConversation: slack.GroupConversation{
+		Conversation: slack.Conversation{ID: "C222222222", NameNormalized: "eng-alerts"},
+		Name:         "eng-alerts",
+	}}})
+
+	tests := []struct {
+		name  string
+		input SearchMessagesInput
+		want  string
+	}{
+		{
+			name: "IDs resolved and timestamps converted",
+			input: SearchMessagesInput{
+				Query:     "deploy failed",
+				InChannel: "C123456789",
+				FromUser:  "U123456789",
+				After:     "1704067200",
+				Before:    "2024-02-01",
+			},
+			want: "in:<#C123456789|general> from:@alice after:2024-01-01 before:2024-02-01 deploy failed",
+		},
+		{
+			name:  "indexed channel name pinned to its ID",
+			input: SearchMessagesInput{InChannel: "#eng-alerts"},
+			want:  "in:<#C222222222|eng-alerts>",
+		},
+		{
+			name:  "names passed through",
+			input: SearchMessagesInput{InChannel: "#random", FromUser: "@bob"},
+			want:  "in:#random from:@bob",
+		},
+		{
+			name:  "raw query only",
+			input: SearchMessagesInput{Query: "from:@carol in:#eng"},
+			want:  "from:@carol in:#eng",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := client.SearchMessages(context.Background(), tt.input)
+			if err != nil {
+				t.Fatalf("SearchMessages failed: %v", err)
+			}
+			if gotQuery != tt.want {
+				t.Errorf("query sent: got %q, want %q", gotQuery, tt.want)
+			}
+			if output.Query != tt.want {
+				t.Errorf("output.Query: got %q, want %q", output.Query, tt.want)
+			}
+		})
+	}
+
+	for _, input := range []SearchMessagesInput{{}, {After: "last tuesday"}} {
+		if _, err := client.SearchMessages(context.Background(), input); err == nil {
+			t.Errorf("SearchMessages(%+v): expected error", input)
+		}
+	}
 }
@@ -0,0 +1,90 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/slack-go/slack"
+)
+
+// ThreadTranscriptInput defines input for building a thread transcript
+type ThreadTranscriptInput struct {
+	Channel   string `json:"channel" jsonschema:"Channel ID or name (e.g., C1234567890 or #general)"`
+	Timestamp string `json:"timestamp" jsonschema:"Thread parent message timestamp (e.g., 1234567890.123456)"`
+
+	WorkspaceSelector
+}
+
+// ThreadTranscriptOutput contains a flattened, human-readable thread transcript
+type ThreadTranscriptOutput struct {
+	File            FileRef `json:"file"`
+	ChannelID       string  `json:"channel_id"`
+	ThreadTimestamp string  `json:"thread_ts"`
+	MessageCount    int     `json:"message_count"`
+	Transcript      string  `json:"transcript"`
+}
+
+// ThreadTranscript fetches every message in a thread and formats it as one
+// "[ISO time] @user: text" line per message, with mentions rendered as names.
+func (c *Service) ThreadTranscript(ctx context.Context, input ThreadTranscriptInput) (ThreadTranscriptOutput, error) {
+	if input.Timestamp == "" {
+		return ThreadTranscriptOutput{}, fmt.Errorf("timestamp is required")
+	}
+
+	channelID, err := c.GetChannelID(ctx, input.Channel, messageChannelTypes...)
+	if err != nil {
+		return ThreadTranscriptOutput{}, err
+	}
+
+	var messages []slack.Message
+	cursor := ""
+	for {
+		page, hasMore, next, err := c.api.GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+			ChannelID: channelID,
+			Timestamp: input.Timestamp,
+			Cursor:    cursor,
+			Limit:     200,
+		})
+		if err != nil {
+			return ThreadTranscriptOutput{}, fmt.Errorf("failed to get thread replies: %w", err)
+		}
+		messages = append(messages, page...)
+		cursor = next
+		if !hasMore || cursor == "" {
+			break
+		}
+	}
+
+	names := c.newUserNameCache(ctx)
+	channelName := func(id string) string { return c.channelName(ctx, id) }
+
+	var sb strings.Builder
+	for _, msg := range messages {
+		author := names.Get(msg.User)
+		if author == "" {
+			author = msg.Username
+		}
+		if author == "" {
+			author = msg.User
+		}
+		fmt.Fprintf(&sb, "[%s] @%s: %s\n",
+			formatSlackTimestamp(msg.Timestamp, c.displayLocation()),
+			author,
+			renderMentions(msg.Text, names.Get, channelName))
+	}
+	transcript := sb.String()
+
+	ref, err := c.responses.WriteText("thread-transcript", transcript)
+	if err != nil {
+		return ThreadTranscriptOutput{}, fmt.Errorf("failed to write response: %w", err)
+	}
+
+	return ThreadTranscriptOutput{
+		File:            ref,
+		ChannelID:       channelID,
+		ThreadTimestamp: input.Timestamp,
+		MessageCount:    len(messages),
+		Transcript:      transcript,
+	}, nil
+}
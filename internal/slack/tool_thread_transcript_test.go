@@ -0,0 +1,82 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+)
+
+func TestThreadTranscript(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	mock.addHandler("/conversations.replies", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		var response map[string]interface{}
+		if r.FormValue("cursor") == "" {
+			response = map[string]interface{}{
+				"ok": true,
+				"messages": []map[string]interface{}{
+					{"type": "message", "user": "U111", "text": "Shall we ship?", "ts": "1704067200.000001", "thread_ts": "1704067200.000001"},
+					{"type": "message", "user": "U222", "text": "<@U111> yes, see <#C333|releases>", "ts": "1704067260.000001", "thread_ts": "1704067200.000001"},
+				},
+				"has_more":          true,
+				"response_metadata": map[string]string{"next_cursor": "page2"},
+			}
+		} else {
+			response = map[string]interface{}{
+				"ok": true,
+				"messages": []map[string]interface{}{
+					{"type": "message", "user": "U111", "text": "Shipped", "ts": "1704067320.000001", "thread_ts": "1704067200.000001"},
+				},
+				"has_more": false,
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/users.info", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		names := map[string]string{"U111": "alice", "U222": "bob"}
+		userID := r.FormValue("user")
+		response := map[string]interface{}{
+			"ok":   true,
+			"user": map[string]interface{}{"id": userID, "name": names[userID]},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	output, err := client.ThreadTranscript(context.Background(), ThreadTranscriptInput{
+		Channel:   "C123456789",
+		Timestamp: "1704067200.000001",
+	})
+	if err != nil {
+		t.Fatalf("ThreadTranscript failed: %v", err)
+	}
+
+	want := "[2024-01-01T00:00:00Z] @alice: Shall we ship?\n" +
+		"[2024-01-01T00:01:00Z] @bob: @alice yes, see #releases\n" +
+		"[2024-01-01T00:02:00Z] @alice: Shipped\n"
+	if output.Transcript != want {
+		t.Errorf("Transcript:\ngot  %q\nwant %q", output.Transcript, want)
+	}
+
+	if output.MessageCount != 3 {
+		t.Errorf("MessageCount: got %d, want 3", output.MessageCount)
+	}
+
+	data, err := os.ReadFile(output.File.Path)
+	if err != nil {
+		t.Fatalf("Failed to read transcript file: %v", err)
+	}
+	if string(data) != want {
+		t.Errorf("file content: got %q, want %q", data, want)
+	}
+}
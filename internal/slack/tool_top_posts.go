@@ -0,0 +1,97 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/slack-go/slack"
+)
+
+// TopPostsInput defines input for finding the most-reacted messages in a channel
+type TopPostsInput struct {
+	Channel string `json:"channel" jsonschema:"Channel ID or name (e.g., C1234567890 or #general)"`
+	Count   int    `json:"count,omitempty" jsonschema:"Number of messages to return (default 10, max 100)"`
+	Oldest  string `json:"oldest,omitempty" jsonschema:"Start of time range (Unix timestamp)"`
+	Latest  string `json:"latest,omitempty" jsonschema:"End of time range (Unix timestamp)"`
+
+	WorkspaceSelector
+}
+
+// TopPost is a message ranked by its total reaction count
+type TopPost struct {
+	MessageInfo
+	TotalReactions int `json:"total_reactions"`
+}
+
+// TopPostsOutput contains the most-reacted messages, highest first
+type TopPostsOutput struct {
+	ChannelID       string    `json:"channel_id"`
+	Posts           []TopPost `json:"posts"`
+	MessagesScanned int       `json:"messages_scanned"`
+}
+
+// TopPosts returns the top-level messages in a channel with the most reactions
+func (c *Service) TopPosts(ctx context.Context, input TopPostsInput) (TopPostsOutput, error) {
+	channelID, err := c.GetChannelID(ctx, input.Channel)
+	if err != nil {
+		return TopPostsOutput{}, err
+	}
+
+	count := 10
+	if input.Count > 0 && input.Count <= 100 {
+		count = input.Count
+	}
+
+	type scored struct {
+		msg   slack.Message
+		total int
+	}
+	var candidates []scored
+	scanned := 0
+	err = c.walkHistory(ctx, channelID, input.Oldest, input.Latest, func(messages []slack.Message) {
+		scanned += len(messages)
+		for _, msg := range messages {
+			total := 0
+			for _, r := range msg.Reactions {
+				total += r.Count
+			}
+			if total > 0 {
+				candidates = append(candidates, scored{msg: msg, total: total})
+			}
+		}
+	})
+	if err != nil {
+		return TopPostsOutput{}, err
+	}
+
+	// Stable, so ties keep history order (most recent first)
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].total > candidates[j].total })
+	if len(candidates) > count {
+		candidates = candidates[:count]
+	}
+
+	names := c.newUserNameCache(ctx)
+	output := TopPostsOutput{
+		ChannelID:       channelID,
+		Posts:           make([]TopPost, 0, len(candidates)),
+		MessagesScanned: scanned,
+	}
+	for _, cand := range candidates {
+		post := TopPost{
+			MessageInfo:    buildMessageInfo(cand.msg, cand.msg.ThreadTimestamp, names.Get(cand.msg.User), c.displayLocation()),
+			TotalReactions: cand.total,
+		}
+		permalink, err := c.api.GetPermalinkContext(ctx, &slack.PermalinkParameters{
+			Channel: channelID,
+			Ts:      cand.msg.Timestamp,
+		})
+		if err != nil {
+			return TopPostsOutput{}, fmt.Errorf("failed to get permalink: %w", err)
+		}
+		post.Permalink = permalink
+		output.Posts = append(output.Posts, post)
+	}
+
+	return output, nil
+}
@@ -0,0 +1,109 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+)
+
+func TestTopPosts(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	reactions := func(counts ...int) []map[string]interface{} {
+		var out []map[string]interface{}
+		for i, n := range counts {
+			out = append(out, map[string]interface{}{"name": []string{"tada", "eyes", "+1"}[i], "count": n})
+		}
+		return out
+	}
+
+	mock.addHandler("/conversations.history", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		var response map[string]interface{}
+		if r.FormValue("cursor") == "" {
+			response = map[string]interface{}{
+				"ok": true,
+				"messages": []map[string]interface{}{
+					{"type": "message", "user": "U111", "text": "minor", "ts": "1700000500.000001", "reactions": reactions(1)},
+					{"type": "message", "user": "U222", "text": "no reactions", "ts": "1700000400.000001"},
+					{"type": "message", "user": "U111", "text": "big news", "ts": "1700000300.000001", "reactions": reactions(5, 3)},
+				},
+				"has_more":          true,
+				"response_metadata": map[string]string{"next_cursor": "page2"},
+			}
+		} else {
+			response = map[string]interface{}{
+				"ok": true,
+				"messages": []map[string]interface{}{
+					{"type": "message", "user": "U222", "text": "launch", "ts": "1700000200.000001", "reactions": reactions(4, 4, 2)},
+					{"type": "message", "user": "U222", "text": "okay", "ts": "1700000100.000001", "reactions": reactions(2)},
+				},
+				"has_more":          false,
+				"response_metadata": map[string]string{"next_cursor": ""},
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/chat.getPermalink", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		response := map[string]interface{}{
+			"ok":        true,
+			"channel":   r.FormValue("channel"),
+			"permalink": "https://example.slack.com/archives/C123456789/p" + r.FormValue("message_ts"),
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/users.info", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		names := map[string]string{"U111": "alice", "U222": "bob"}
+		userID := r.FormValue("user")
+		response := map[string]interface{}{
+			"ok":   true,
+			"user": map[string]interface{}{"id": userID, "name": names[userID]},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	output, err := client.TopPosts(context.Background(), TopPostsInput{Channel: "C123456789", Count: 3})
+	if err != nil {
+		t.Fatalf("TopPosts failed: %v", err)
+	}
+
+	if output.MessagesScanned != 5 {
+		t.Errorf("MessagesScanned: got %d, want 5", output.MessagesScanned)
+	}
+
+	tests := []struct {
+		text     string
+		total    int
+		userName string
+	}{
+		{"launch", 10, "bob"},
+		{"big news", 8, "alice"},
+		{"okay", 2, "bob"},
+	}
+	if len(output.Posts) != len(tests) {
+		t.Fatalf("Posts: got %d, want %d", len(output.Posts), len(tests))
+	}
+	for i, tt := range tests {
+		got := output.Posts[i]
+		if got.Text != tt.text || got.TotalReactions != tt.total || got.UserName != tt.userName {
+			t.Errorf("Posts[%d]: got %q/%d/%s, want %q/%d/%s",
+				i, got.Text, got.TotalReactions, got.UserName, tt.text, tt.total, tt.userName)
+		}
+		if got.Permalink != "https://example.slack.com/archives/C123456789/p"+got.RawTimestamp {
+			t.Errorf("Posts[%d].Permalink: got %q", i, got.Permalink)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/slack-go/slack"
+)
+
+// UserThreadsInput defines input for finding threads a user took part in
+type UserThreadsInput struct {
+	Channel string `json:"channel" jsonschema:"Channel ID or name (e.g., C1234567890 or #general)"`
+	User    string `json:"user,omitempty" jsonschema:"User ID (e.g., U1234567890)"`
+	Email   string `json:"email,omitempty" jsonschema:"User email address (alternative to user)"`
+	Oldest  string `json:"oldest,omitempty" jsonschema:"Start of time range (Unix timestamp)"`
+	Latest  string `json:"latest,omitempty" jsonschema:"End of time range (Unix timestamp)"`
+
+	WorkspaceSelector
+}
+
+// UserThread is a thread parent the user started or replied to
+type UserThread struct {
+	MessageInfo
+	StartedByUser bool `json:"started_by_user"`
+}
+
+// UserThreadsOutput contains the matching thread parents, most recent first
+type UserThreadsOutput struct {
+	ChannelID      string       `json:"channel_id"`
+	UserID         string       `json:"user_id"`
+	Threads        []UserThread `json:"threads"`
+	TotalCount     int          `json:"total_count"`
+	ThreadsScanned int          `json:"threads_scanned"`
+}
+
+// UserThreads lists threads in a channel that a user started or replied to.
+// Participation is read from each parent's reply_users; threads are only
+// expanded when that list is missing or may have been truncated.
+func (c *Service) UserThreads(ctx context.Context, input UserThreadsInput) (UserThreadsOutput, error) {
+	channelID, err := c.GetChannelID(ctx, input.Channel)
+	if err != nil {
+		return UserThreadsOutput{}, err
+	}
+
+	userID := input.User
+	if userID == "" {
+		if input.Email == "" {
+			return UserThreadsOutput{}, fmt.Errorf("either user ID or email is required")
+		}
+		user, err := c.api.GetUserByEmailContext(ctx, input.Email)
+		if err != nil {
+			return UserThreadsOutput{}, fmt.Errorf("failed to get user: %w", err)
+		}
+		userID = user.ID
+	}
+
+	var parents []slack.Message
+	err = c.walkHistory(ctx, channelID, input.Oldest, input.Latest, func(messages []slack.Message) {
+		for _, msg := range messages {
+			if msg.ReplyCount > 0 {
+				parents = append(parents, msg)
+			}
+		}
+	})
+	if err != nil {
+		return UserThreadsOutput{}, err
+	}
+
+	names := c.newUserNameCache(ctx)
+	output := UserThreadsOutput{
+		ChannelID:      channelID,
+		UserID:         userID,
+		Threads:        []UserThread{},
+		ThreadsScanned: len(parents),
+	}
+	for _, parent := range parents {
+		started := parent.User == userID
+		participated := started || slices.Contains(parent.ReplyUsers, userID)
+		if !participated && !replyUsersComplete(parent) {
+			participated, err = c.threadHasReplyFrom(ctx, channelID, parent.Timestamp, userID)
+			if err != nil {
+				return UserThreadsOutput{}, err
+			}
+		}
+		if !participated {
+			continue
+		}
+		output.Threads = append(output.Threads, UserThread{
+			MessageInfo:   buildMessageInfo(parent, "", names.Get(parent.User), c.displayLocation()),
+			StartedByUser: started,
+		})
+	}
+	output.TotalCount = len(output.Threads)
+
+	return output, nil
+}
+
+// replyUsersLimit is the most users Slack lists in a thread parent's
+// reply_users. slack-go doesn't decode reply_users_count, so a list this
+// long has to be treated as possibly missing some repliers.
+const replyUsersLimit = 5
+
+// replyUsersComplete reports whether msg's reply_users names everyone who
+// replied to the thread
+func replyUsersComplete(msg slack.Message) bool {
+	return len(msg.ReplyUsers) > 0 && len(msg.ReplyUsers) < replyUsersLimit
+}
+
+// threadHasReplyFrom pages through a thread's replies looking for one by userID
+func (c *Service) threadHasReplyFrom(ctx context.Context, channelID, threadTs, userID string) (bool, error) {
+	cursor := ""
+	for {
+		replies, hasMore, next, err := c.api.GetConversationRepliesContext(ctx, &slack.GetConversationRepliesParameters{
+			ChannelID: channelID,
+			Timestamp: threadTs,
+			Cursor:    cursor,
+			Limit:     200,
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to get thread replies: %w", err)
+		}
+
+		for _, reply := range replies {
+			if reply.User == userID {
+				return true, nil
+			}
+		}
+
+		if !hasMore || next == "" {
+			return false, nil
+		}
+		cursor = next
+	}
+}
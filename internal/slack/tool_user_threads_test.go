@@ -0,0 +1,155 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+)
+
+func TestUserThreads(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	mock.addHandler("/conversations.history", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"type": "message", "user": "U222", "text": "no thread", "ts": "1700000400.000001"},
+				{"type": "message", "user": "U222", "text": "alice replied", "ts": "1700000300.000001", "thread_ts": "1700000300.000001", "reply_count": 2, "reply_users": []string{"U222", "U111"}},
+				{"type": "message", "user": "U222", "text": "bob only", "ts": "1700000200.000001", "thread_ts": "1700000200.000001", "reply_count": 1, "reply_users": []string{"U222"}},
+				{"type": "message", "user": "U333", "text": "no reply_users", "ts": "1700000100.000001", "thread_ts": "1700000100.000001", "reply_count": 1},
+			},
+			"has_more": false,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	var expanded []string
+	mock.addHandler("/conversations.replies", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		expanded = append(expanded, r.FormValue("ts"))
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"type": "message", "user": "U333", "text": "parent", "ts": "1700000100.000001", "thread_ts": "1700000100.000001"},
+				{"type": "message", "user": "U222", "text": "reply", "ts": "1700000150.000001", "thread_ts": "1700000100.000001"},
+			},
+			"has_more": false,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/users.info", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		names := map[string]string{"U111": "alice", "U222": "bob"}
+		userID := r.FormValue("user")
+		response := map[string]interface{}{
+			"ok":   true,
+			"user": map[string]interface{}{"id": userID, "name": names[userID]},
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	output, err := client.UserThreads(context.Background(), UserThreadsInput{Channel: "C123456789", User: "U111"})
+	if err != nil {
+		t.Fatalf("UserThreads failed: %v", err)
+	}
+
+	if output.ThreadsScanned != 3 {
+		t.Errorf("ThreadsScanned: got %d, want 3", output.ThreadsScanned)
+	}
+	if output.TotalCount != 1 || len(output.Threads) != 1 {
+		t.Fatalf("TotalCount: got %d (%d threads), want 1", output.TotalCount, len(output.Threads))
+	}
+	thread := output.Threads[0]
+	if thread.RawTimestamp != "1700000300.000001" {
+		t.Errorf("RawTimestamp: got %q, want 1700000300.000001", thread.RawTimestamp)
+	}
+	if thread.StartedByUser {
+		t.Error("StartedByUser: got true, want false")
+	}
+	if len(expanded) != 1 || expanded[0] != "1700000100.000001" {
+		t.Errorf("expanded threads: got %v, want only the one without reply_users", expanded)
+	}
+}
+
+func TestUserThreads_ExpandsTruncatedReplyUsers(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	mock.addHandler("/conversations.history", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{
+					"type": "message", "user": "U222", "text": "busy thread", "ts": "1700000300.000001", "thread_ts": "1700000300.000001",
+					"reply_count": 9, "reply_users_count": 6, "reply_users": []string{"U201", "U202", "U203", "U204", "U205"},
+				},
+				{
+					"type": "message", "user": "U222", "text": "small thread", "ts": "1700000200.000001", "thread_ts": "1700000200.000001",
+					"reply_count": 4, "reply_users_count": 4, "reply_users": []string{"U201", "U202", "U203", "U204"},
+				},
+			},
+			"has_more": false,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	var expanded []string
+	mock.addHandler("/conversations.replies", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		expanded = append(expanded, r.FormValue("ts"))
+		response := map[string]interface{}{
+			"ok": true,
+			"messages": []map[string]interface{}{
+				{"type": "message", "user": "U222", "text": "busy thread", "ts": "1700000300.000001", "thread_ts": "1700000300.000001"},
+				{"type": "message", "user": "U111", "text": "sixth replier", "ts": "1700000350.000001", "thread_ts": "1700000300.000001"},
+			},
+			"has_more": false,
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mock.addHandler("/users.info", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "user_not_found"})
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	output, err := client.UserThreads(context.Background(), UserThreadsInput{Channel: "C123456789", User: "U111"})
+	if err != nil {
+		t.Fatalf("UserThreads failed: %v", err)
+	}
+
+	if output.TotalCount != 1 || output.Threads[0].RawTimestamp != "1700000300.000001" {
+		t.Errorf("Threads: got %+v, want only the busy thread", output.Threads)
+	}
+	if len(expanded) != 1 || expanded[0] != "1700000300.000001" {
+		t.Errorf("expanded threads: got %v, want only the one with a full reply_users list", expanded)
+	}
+}
+
+func TestUserThreads_RequiresUser(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	_, err := client.UserThreads(context.Background(), UserThreadsInput{Channel: "C123456789"})
+	if err == nil {
+		t.Error("expected error when neither user nor email is given")
+	}
+}
@@ -0,0 +1,36 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+)
+
+// AuthTestInput defines input for identifying the authenticated user
+type AuthTestInput struct {
+	WorkspaceSelector
+}
+
+// AuthTestOutput describes the identity the configured token maps to
+type AuthTestOutput struct {
+	UserID string `json:"user_id"`
+	User   string `json:"user"`
+	TeamID string `json:"team_id"`
+	Team   string `json:"team"`
+	URL    string `json:"url"`
+}
+
+// AuthTest reports which user and workspace the configured token belongs to
+func (c *Service) AuthTest(ctx context.Context, input AuthTestInput) (AuthTestOutput, error) {
+	auth, err := c.api.AuthTestContext(ctx)
+	if err != nil {
+		return AuthTestOutput{}, fmt.Errorf("failed to test auth: %w", err)
+	}
+
+	return AuthTestOutput{
+		UserID: auth.UserID,
+		User:   auth.User,
+		TeamID: auth.TeamID,
+		Team:   auth.Team,
+		URL:    auth.URL,
+	}, nil
+}
@@ -0,0 +1,68 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+)
+
+func TestAuthTest(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	mock.addHandler("/auth.test", func(w http.ResponseWriter, r *http.Request) {
+		response := map[string]interface{}{
+			"ok":      true,
+			"url":     "https://example.slack.com/",
+			"team":    "Example",
+			"user":    "alice",
+			"team_id": "T123456789",
+			"user_id": "U123456789",
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	output, err := client.AuthTest(context.Background(), AuthTestInput{})
+	if err != nil {
+		t.Fatalf("AuthTest failed: %v", err)
+	}
+
+	want := AuthTestOutput{
+		UserID: "U123456789",
+		User:   "alice",
+		TeamID: "T123456789",
+		Team:   "Example",
+		URL:    "https://example.slack.com/",
+	}
+	if output != want {
+		t.Errorf("got %+v, want %+v", output, want)
+	}
+}
+
+func TestAuthTest_InvalidAuth(t *testing.T) {
+	mock := newMockSlackServer()
+	defer mock.close()
+
+	mock.addHandler("/auth.test", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"ok": false, "error": "invalid_auth"})
+	})
+
+	client, _, responsesDir := newTestClient(t, mock)
+	defer os.RemoveAll(responsesDir)
+
+	_, err := client.AuthTest(context.Background(), AuthTestInput{})
+	if err == nil {
+		t.Fatal("got nil error, want invalid_auth")
+	}
+
+	if authErr := matchAuthError(err); authErr == nil || authErr.Code != "invalid_auth" {
+		t.Errorf("error: got %v, want one matching invalid_auth", err)
+	}
+}
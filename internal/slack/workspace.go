@@ -0,0 +1,12 @@
+package slack
+
+// WorkspaceSelector is embedded in every tool input to choose which configured
+// Slack workspace handles the call
+type WorkspaceSelector struct {
+	Workspace string `json:"workspace,omitempty" jsonschema:"Name of the configured Slack workspace to use. Omit to use the default workspace"`
+}
+
+// WorkspaceName returns the requested workspace, or "" for the default
+func (w WorkspaceSelector) WorkspaceName() string {
+	return w.Workspace
+}
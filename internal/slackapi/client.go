@@ -1,23 +1,65 @@
 package slackapi
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/slack-go/slack"
 	"go.uber.org/zap"
 )
 
+// Config holds optional settings for the Slack API client
+type Config struct {
+	// APIURL overrides the Slack API base URL, for deployments that are not
+	// served from slack.com. It must end with a slash, as the Slack SDK
+	// appends method names to it directly.
+	APIURL string
+
+	// ProxyURL routes API requests through this proxy. When empty, the
+	// HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables apply.
+	ProxyURL string
+}
+
 // NewClient creates a new Slack API client with optional cookie authentication
-func NewClient(token, cookie string, logger *zap.Logger) *slack.Client {
+func NewClient(token, cookie string, logger *zap.Logger, cfg Config) (*slack.Client, error) {
 	opts := []slack.Option{}
 
+	if cfg.APIURL != "" {
+		if err := validateAPIURL(cfg.APIURL); err != nil {
+			return nil, err
+		}
+		logger.Info("Using custom Slack API URL", zap.String("url", cfg.APIURL))
+		opts = append(opts, slack.OptionAPIURL(cfg.APIURL))
+	}
+
+	transport, err := newTransport(cfg.ProxyURL)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.ProxyURL != "" {
+		logger.Info("Using HTTP proxy for Slack client", zap.String("proxy", redactProxyURL(cfg.ProxyURL)))
+	}
+
+	var rt http.RoundTripper = newRateLimitTransport(transport, logger)
 	if cookie != "" {
 		logger.Info("Using cookie authentication for Slack client")
-		httpClient := &http.Client{
-			Transport: newCookieTransport(cookie, logger),
-		}
-		opts = append(opts, slack.OptionHTTPClient(httpClient))
+		rt = newCookieTransport(rt, cookie, logger)
 	}
+	opts = append(opts, slack.OptionHTTPClient(&http.Client{Transport: rt}))
 
-	return slack.New(token, opts...)
+	return slack.New(token, opts...), nil
+}
+
+// validateAPIURL checks that u is an absolute http(s) URL ending with a slash
+func validateAPIURL(u string) error {
+	parsed, err := url.Parse(u)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return fmt.Errorf("invalid Slack API URL %q: must be an absolute http(s) URL such as https://slack.com/api/", u)
+	}
+	if !strings.HasSuffix(parsed.Path, "/") {
+		return fmt.Errorf("invalid Slack API URL %q: must end with a slash (e.g. %s/)", u, strings.TrimRight(u, "/"))
+	}
+	return nil
 }
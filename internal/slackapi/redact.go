@@ -0,0 +1,65 @@
+package slackapi
+
+import (
+	"regexp"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var (
+	// reSlackSecret matches Slack tokens (xoxb-, xoxc-, xoxp-, ...) and
+	// xoxd- session cookies, including URL-encoded forms
+	reSlackSecret = regexp.MustCompile(`\b(xox[a-z])-[A-Za-z0-9%._/+=-]+`)
+	// reTokenParam matches a token query or form parameter
+	reTokenParam = regexp.MustCompile(`\b(token=)[^&\s"']+`)
+)
+
+// Redact masks Slack tokens and cookies in s so it can be logged
+func Redact(s string) string {
+	s = reTokenParam.ReplaceAllString(s, "${1}REDACTED")
+	return reSlackSecret.ReplaceAllString(s, "${1}-REDACTED")
+}
+
+// NewRedactingCore wraps core so that log messages and string and error
+// fields have Slack secrets masked before they are written.
+func NewRedactingCore(core zapcore.Core) zapcore.Core {
+	return redactingCore{core}
+}
+
+type redactingCore struct {
+	zapcore.Core
+}
+
+func (c redactingCore) With(fields []zapcore.Field) zapcore.Core {
+	return redactingCore{c.Core.With(redactFields(fields))}
+}
+
+func (c redactingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c redactingCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	ent.Message = Redact(ent.Message)
+	return c.Core.Write(ent, redactFields(fields))
+}
+
+// redactFields returns fields with string and error values redacted
+func redactFields(fields []zapcore.Field) []zapcore.Field {
+	out := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		switch f.Type {
+		case zapcore.StringType:
+			f.String = Redact(f.String)
+		case zapcore.ErrorType:
+			if err, ok := f.Interface.(error); ok && err != nil {
+				f = zap.String(f.Key, Redact(err.Error()))
+			}
+		}
+		out[i] = f
+	}
+	return out
+}
@@ -0,0 +1,87 @@
+package slackapi
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"token query param", "https://slack.com/api/conversations.list?token=abc123&limit=10", "https://slack.com/api/conversations.list?token=REDACTED&limit=10"},
+		{"user token", "using xoxc-1234-5678-abcdef", "using xoxc-REDACTED"},
+		{"oauth token", "Bearer xoxp-1-2-3-abc", "Bearer xoxp-REDACTED"},
+		{"encoded cookie", "d=xoxd-abc%2Fdef%3D", "d=xoxd-REDACTED"},
+		{"nothing secret", "channel_not_found", "channel_not_found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Redact(tt.in); got != tt.want {
+				t.Errorf("Redact(%q): got %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCookieTransport_RedactsLoggedURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	transport := newCookieTransport(http.DefaultTransport, "xoxd-secret", zap.New(core))
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/api/auth.test?token=xoxc-secret-token", nil)
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip failed: %v", err)
+	}
+	resp.Body.Close()
+
+	entries := logs.FilterMessage("Slack API request").All()
+	if len(entries) != 1 {
+		t.Fatalf("log entries: got %d, want 1", len(entries))
+	}
+	url := entries[0].ContextMap()["url"].(string)
+	if strings.Contains(url, "secret") {
+		t.Errorf("logged url leaks the token: %s", url)
+	}
+}
+
+func TestRedactingCore(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := zap.New(NewRedactingCore(core)).With(zap.String("auth", "xoxp-1-2-3"))
+
+	logger.Warn("request failed for xoxc-abc",
+		zap.Error(errors.New(`Post "https://slack.com/api/x?token=xoxc-abc": timeout`)),
+		zap.Int("attempt", 2))
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("log entries: got %d, want 1", len(entries))
+	}
+	e := entries[0]
+	if e.Message != "request failed for xoxc-REDACTED" {
+		t.Errorf("message: got %q", e.Message)
+	}
+	fields := e.ContextMap()
+	if fields["auth"] != "xoxp-REDACTED" {
+		t.Errorf("auth field: got %v", fields["auth"])
+	}
+	if got := fields["error"].(string); strings.Contains(got, "abc") {
+		t.Errorf("error field leaks the token: %s", got)
+	}
+	if fields["attempt"] != int64(2) {
+		t.Errorf("attempt field: got %v, want 2", fields["attempt"])
+	}
+}
@@ -1,8 +1,12 @@
 package slackapi
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
 	"path"
+	"slices"
+	"strings"
 
 	"go.uber.org/zap"
 )
@@ -18,15 +22,88 @@ func (t *cookieTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	req.Header.Set("Cookie", "d="+t.cookie)
 	t.logger.Debug("Slack API request",
 		zap.String("api_method", path.Base(req.URL.Path)),
-		zap.String("url", req.URL.String()))
+		zap.String("url", Redact(req.URL.String())))
 	return t.transport.RoundTrip(req)
 }
 
 // newCookieTransport creates a transport with cookie authentication
-func newCookieTransport(cookie string, logger *zap.Logger) *cookieTransport {
+func newCookieTransport(transport http.RoundTripper, cookie string, logger *zap.Logger) *cookieTransport {
 	return &cookieTransport{
-		transport: http.DefaultTransport,
+		transport: transport,
 		cookie:    cookie,
 		logger:    logger,
 	}
 }
+
+// rateLimitTransport wraps an http.RoundTripper to log Slack's rate-limit
+// response headers at debug level
+type rateLimitTransport struct {
+	transport http.RoundTripper
+	logger    *zap.Logger
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil || !t.logger.Core().Enabled(zap.DebugLevel) {
+		return resp, err
+	}
+	if fields := rateLimitFields(resp.Header); len(fields) > 0 {
+		fields = append(fields,
+			zap.String("api_method", path.Base(req.URL.Path)),
+			zap.Int("status", resp.StatusCode))
+		t.logger.Debug("Slack API rate limit headers", fields...)
+	}
+	return resp, nil
+}
+
+// newRateLimitTransport creates a transport that logs rate-limit headers
+func newRateLimitTransport(transport http.RoundTripper, logger *zap.Logger) *rateLimitTransport {
+	return &rateLimitTransport{
+		transport: transport,
+		logger:    logger,
+	}
+}
+
+// rateLimitFields returns a log field for Retry-After and for each
+// X-Rate-Limit-* or X-RateLimit-* header, in a stable order
+func rateLimitFields(h http.Header) []zap.Field {
+	var names []string
+	for name := range h {
+		lower := strings.ToLower(name)
+		if lower == "retry-after" || strings.HasPrefix(lower, "x-rate-limit-") || strings.HasPrefix(lower, "x-ratelimit-") {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	fields := make([]zap.Field, 0, len(names))
+	for _, name := range names {
+		fields = append(fields, zap.String(strings.ToLower(name), h.Get(name)))
+	}
+	return fields
+}
+
+// newTransport returns a copy of the default transport that sends requests
+// through proxyURL, or through the proxy named by the environment when
+// proxyURL is empty.
+func newTransport(proxyURL string) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	if proxyURL != "" {
+		u, err := url.Parse(proxyURL)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q: must be an absolute URL such as http://proxy.example.com:3128", redactProxyURL(proxyURL))
+		}
+		transport.Proxy = http.ProxyURL(u)
+	}
+	return transport, nil
+}
+
+// redactProxyURL hides any password in a proxy URL so it can be logged
+func redactProxyURL(proxyURL string) string {
+	u, err := url.Parse(proxyURL)
+	if err != nil {
+		return "<unparseable>"
+	}
+	return u.Redacted()
+}
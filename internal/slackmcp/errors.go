@@ -0,0 +1,72 @@
+package slackmcp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.mcconachie.co/slack-4-agents/internal/slack"
+	"go.uber.org/zap"
+)
+
+// authErrorMetaKey is the _meta key under which a tool result that failed
+// because of a Slack authentication problem carries an authErrorDetail, so
+// clients can react to the code rather than parse the error text. It lives
+// in _meta because structuredContent must match the tool's output schema.
+const authErrorMetaKey = "slack_auth_error"
+
+// authErrorDetail describes an authentication failure and how to fix it
+type authErrorDetail struct {
+	Code         string   `json:"code"`
+	Message      string   `json:"message"`
+	Remediation  string   `json:"remediation"`
+	NeededScopes []string `json:"needed_scopes,omitempty"`
+}
+
+// toolErrorKey is the context key for the toolError recorder
+type toolErrorKey struct{}
+
+// toolError records the error a tool handler returned. The SDK keeps only the
+// error text in the result, so authErrorMiddleware reads the original here.
+type toolError struct {
+	err error
+}
+
+// wrapError applies slack.WrapError and records the result for authErrorMiddleware
+func wrapError(ctx context.Context, logger *zap.Logger, operation string, err error) error {
+	err = slack.WrapError(logger, operation, err)
+	if rec, ok := ctx.Value(toolErrorKey{}).(*toolError); ok {
+		rec.err = err
+	}
+	return err
+}
+
+// authErrorMiddleware adds an authErrorDetail to the _meta of tool results
+// whose handler failed with a *slack.AuthError. The text content is left
+// unchanged.
+func authErrorMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" {
+			return next(ctx, method, req)
+		}
+
+		rec := &toolError{}
+		result, err := next(context.WithValue(ctx, toolErrorKey{}, rec), method, req)
+
+		res, ok := result.(*mcp.CallToolResult)
+		var authErr *slack.AuthError
+		if err != nil || !ok || !res.IsError || !errors.As(rec.err, &authErr) {
+			return result, err
+		}
+		if res.Meta == nil {
+			res.Meta = mcp.Meta{}
+		}
+		res.Meta[authErrorMetaKey] = authErrorDetail{
+			Code:         authErr.Code,
+			Message:      authErr.Error(),
+			Remediation:  authErr.Message,
+			NeededScopes: authErr.NeededScopes,
+		}
+		return res, nil
+	}
+}
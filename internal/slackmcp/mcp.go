@@ -2,14 +2,19 @@ package slackmcp
 
 import (
 	"context"
+	"errors"
+	"io/fs"
+	"path"
+	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"go.mcconachie.co/slack-4-agents/internal/slack"
 	"go.uber.org/zap"
 )
 
-// NewServer creates an MCP server with all Slack tools registered
-func NewServer(logger *zap.Logger, client *slack.Service) *mcp.Server {
+// NewServer creates an MCP server with all Slack tools registered, serving
+// each call from the workspace its input selects
+func NewServer(logger *zap.Logger, workspaces *Workspaces) *mcp.Server {
 	logger.Info("Starting MCP server")
 	server := mcp.NewServer(
 		&mcp.Implementation{
@@ -19,74 +24,212 @@ func NewServer(logger *zap.Logger, client *slack.Service) *mcp.Server {
 		nil,
 	)
 
-	registerTools(server, client, logger)
+	server.AddReceivingMiddleware(authErrorMiddleware)
+	registerTools(server, workspaces, logger)
+	registerResources(server, workspaces.responses(), logger)
 	logger.Info("Slack 4 Agents server initialized, starting transport")
 	return server
 }
 
 // registerTools registers all Slack tools with the MCP server
-func registerTools(server *mcp.Server, client *slack.Service, logger *zap.Logger) {
+func registerTools(server *mcp.Server, workspaces *Workspaces, logger *zap.Logger) {
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "slack_list_channels",
-		Description: "List Slack channels the user has access to. Returns channel names, IDs, topics, and member counts.",
-	}, func(ctx context.Context, req *mcp.CallToolRequest, input slack.ListChannelsInput) (*mcp.CallToolResult, slack.ListChannelsOutput, error) {
-		output, err := client.ListChannels(ctx, input)
-		return nil, output, slack.WrapError(logger, "list_channels", err)
-	})
+		Description: "List Slack channels the user has access to. Returns channel names, IDs, topics, and member counts, inline for small results or in a file otherwise.",
+	}, handle(workspaces, logger, "list_channels", (*slack.Service).ListChannels))
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "slack_read_history",
 		Description: "Read recent messages from a Slack channel. Returns messages with author info, timestamps, and thread details. Supports time-range filtering and pagination (max 100 per call). Best for browsing recent activity or reading a specific time window.",
-	}, func(ctx context.Context, req *mcp.CallToolRequest, input slack.ReadHistoryInput) (*mcp.CallToolResult, slack.ReadHistoryOutput, error) {
-		output, err := client.ReadHistory(ctx, input)
-		return nil, output, slack.WrapError(logger, "read_history", err)
-	})
+	}, handle(workspaces, logger, "read_history", (*slack.Service).ReadHistory))
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "slack_search_messages",
-		Description: "Search for messages across the Slack workspace. Supports Slack search syntax like from:@user, in:#channel, before:2024-01-01.",
-	}, func(ctx context.Context, req *mcp.CallToolRequest, input slack.SearchMessagesInput) (*mcp.CallToolResult, slack.SearchMessagesOutput, error) {
-		output, err := client.SearchMessages(ctx, input)
-		return nil, output, slack.WrapError(logger, "search_messages", err)
-	})
+		Description: "Search for messages across the Slack workspace. Supports Slack search syntax like from:@user, in:#channel, before:2024-01-01, or the after, before, from_user and in_channel fields. Large result sets are written to a file.",
+	}, handle(workspaces, logger, "search_messages", (*slack.Service).SearchMessages))
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "slack_get_user",
 		Description: "Look up a Slack user by ID or email address. Returns profile information including name, title, status, and timezone.",
-	}, func(ctx context.Context, req *mcp.CallToolRequest, input slack.GetUserInput) (*mcp.CallToolResult, slack.GetUserOutput, error) {
-		output, err := client.GetUser(ctx, input)
-		return nil, output, slack.WrapError(logger, "get_user", err)
-	})
+	}, handle(workspaces, logger, "get_user", (*slack.Service).GetUser))
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "slack_get_permalink",
 		Description: "Get a permanent link (URL) to a specific Slack message. Useful for sharing or referencing messages.",
-	}, func(ctx context.Context, req *mcp.CallToolRequest, input slack.GetPermalinkInput) (*mcp.CallToolResult, slack.GetPermalinkOutput, error) {
-		output, err := client.GetPermalink(ctx, input)
-		return nil, output, slack.WrapError(logger, "get_permalink", err)
-	})
+	}, handle(workspaces, logger, "get_permalink", (*slack.Service).GetPermalink))
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "slack_read_thread",
 		Description: "Read all replies in a Slack thread. Use the thread parent's timestamp from slack_read_history (messages with reply_count > 0).",
-	}, func(ctx context.Context, req *mcp.CallToolRequest, input slack.ReadThreadInput) (*mcp.CallToolResult, slack.ReadThreadOutput, error) {
-		output, err := client.ReadThread(ctx, input)
-		return nil, output, slack.WrapError(logger, "read_thread", err)
-	})
+	}, handle(workspaces, logger, "read_thread", (*slack.Service).ReadThread))
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "slack_export_channel",
-		Description: "Export a Slack channel's complete history (including all threads and reactions) to JSON-lines files. Automatically paginates through the full channel. Best for bulk analysis or when you need the full picture.",
-	}, func(ctx context.Context, req *mcp.CallToolRequest, input slack.ExportChannelInput) (*mcp.CallToolResult, slack.ExportChannelOutput, error) {
-		output, err := client.ExportChannel(ctx, input)
-		return nil, output, slack.WrapError(logger, "export_channel", err)
-	})
+		Description: "Export a Slack channel's complete history (including all threads and reactions) to JSON-lines files. Automatically paginates through the full channel. Best for bulk analysis or when you need the full picture. Set split_by_day to write one file per calendar day, or estimate to size an export before running it.",
+	}, handle(workspaces, logger, "export_channel", (*slack.Service).ExportChannel))
 
 	mcp.AddTool(server, &mcp.Tool{
 		Name:        "slack_read_canvas",
-		Description: "Read a Slack canvas document. Provide either a channel (to read the channel's canvas) or a file_id (for standalone canvases). Returns the canvas content as plain text.",
-	}, func(ctx context.Context, req *mcp.CallToolRequest, input slack.ReadCanvasInput) (*mcp.CallToolResult, slack.ReadCanvasOutput, error) {
-		output, err := client.ReadCanvas(ctx, input)
-		return nil, output, slack.WrapError(logger, "read_canvas", err)
+		Description: "Read a Slack canvas document. Provide either a channel (to read the channel's canvas) or a file_id (for standalone canvases). Returns the canvas content as plain text; set max_chunk_bytes to split a large canvas into several files.",
+	}, handle(workspaces, logger, "read_canvas", (*slack.Service).ReadCanvas))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "slack_list_all_scheduled",
+		Description: "List all pending scheduled messages across every channel, with channel names resolved. Useful for a workspace-wide review of scheduled posts. Set channel to list a single channel.",
+	}, handle(workspaces, logger, "list_all_scheduled", (*slack.Service).ListAllScheduled))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "slack_resolve_channel_refs",
+		Description: "Resolve channel references in arbitrary text. Finds <#C123|name> mentions and bare #channel-name references, returns the text rewritten in canonical <#ID|name> form plus a list of each reference's channel ID and name.",
+	}, handle(workspaces, logger, "resolve_channel_refs", (*slack.Service).ResolveChannelRefs))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "slack_canvas_stats",
+		Description: "Get size statistics for a Slack canvas (word, character, heading, and section counts plus an estimated token count) without returning its content. Provide either a channel or a file_id. Use before slack_read_canvas to decide whether a canvas fits in context.",
+	}, handle(workspaces, logger, "canvas_stats", (*slack.Service).CanvasStats))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "slack_thread_transcript",
+		Description: "Fetch an entire Slack thread as a flattened plain-text transcript, one \"[time] @user: text\" line per message with user and channel mentions rendered as names. Also writes the transcript to a .txt file. The most compact way to feed a thread to an LLM.",
+	}, handle(workspaces, logger, "thread_transcript", (*slack.Service).ThreadTranscript))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "slack_large_files",
+		Description: "List files in a channel at or above a size threshold (default 10 MB), largest first, with uploader names. Useful for finding what is consuming workspace storage.",
+	}, handle(workspaces, logger, "large_files", (*slack.Service).LargeFiles))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "slack_inactive_members",
+		Description: "List channel members who have not posted in the channel since a given time (default: the last 30 days). Thread replies that were not broadcast to the channel are not counted.",
+	}, handle(workspaces, logger, "inactive_members", (*slack.Service).InactiveMembers))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "slack_channels_by_prefix",
+		Description: "List all channels whose name starts with a prefix (e.g. team- or proj-), with their IDs. Useful for acting on a group of channels that share a naming convention.",
+	}, handle(workspaces, logger, "channels_by_prefix", (*slack.Service).ChannelsByPrefix))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "slack_top_posts",
+		Description: "Find the most-reacted messages in a channel over a time range, ranked by total reaction count, with usernames and permalinks. Useful for highlights and recaps.",
+	}, handle(workspaces, logger, "top_posts", (*slack.Service).TopPosts))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "slack_list_pins",
+		Description: "List the messages pinned in a channel, with author names. Pins are often a curated summary of a channel's key decisions and links.",
+	}, handle(workspaces, logger, "list_pins", (*slack.Service).ListPins))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "slack_user_threads",
+		Description: "List threads in a channel that a user (by ID or email) started or replied to, over an optional time range. Useful for reviewing someone's contributions.",
+	}, handle(workspaces, logger, "user_threads", (*slack.Service).UserThreads))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "slack_check_links",
+		Description: "Find links posted in a channel and check whether each is still reachable (HTTP HEAD). Slack-internal links are skipped. Useful for spotting dead links in docs or resource channels.",
+	}, handle(workspaces, logger, "check_links", (*slack.Service).CheckLinks))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "slack_channel_manifest",
+		Description: "Write a JSON manifest of a channel's metadata (name, topic, purpose, privacy), members (with emails where available; members whose profile lookup failed are listed as unresolved), pinned messages and bookmarks. Useful when migrating or re-creating a channel.",
+	}, handle(workspaces, logger, "channel_manifest", (*slack.Service).ChannelManifest))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "slack_resolve_export",
+		Description: "Rewrite an existing JSON-lines export with missing user names filled in and raw <@U...>/<#C...> mentions rendered as readable @name/#name. Writes a new file; the original is unchanged.",
+	}, handle(workspaces, logger, "resolve_export", (*slack.Service).ResolveExport))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "slack_get_message",
+		Description: "Fetch a single message by channel and timestamp, with reactions, files and author name. Use after search or when you have a permalink's channel and timestamp.",
+	}, handle(workspaces, logger, "get_message", (*slack.Service).GetMessage))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "slack_resolve_permalink",
+		Description: "Convert a Slack message permalink into its channel ID and message timestamp (plus the parent thread timestamp for replies), for use with the other tools.",
+	}, handle(workspaces, logger, "resolve_permalink", (*slack.Service).ResolvePermalink))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "slack_whoami",
+		Description: "Show which Slack user and workspace the configured token belongs to. Call this first to confirm the identity and surface authentication problems early.",
+	}, handle(workspaces, logger, "whoami", (*slack.Service).AuthTest))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "slack_list_reminders",
+		Description: "List reminders created by or for you, with their text, due time and whether they are complete. Completed reminders are left out unless include_complete is set.",
+	}, handle(workspaces, logger, "list_reminders", (*slack.Service).ListReminders))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "slack_get_channel_canvas_metadata",
+		Description: "Check whether a channel has a canvas and get its file ID, title, size and emptiness without downloading it. Use before slack_read_canvas to decide whether a canvas is worth reading.",
+	}, handle(workspaces, logger, "get_channel_canvas_metadata", (*slack.Service).GetChannelCanvasMetadata))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "slack_search_in_channel",
+		Description: "Search messages within one channel. The channel (ID or name) is resolved before searching, so there is no need to write Slack's in: modifier by hand.",
+	}, handle(workspaces, logger, "search_in_channel", (*slack.Service).SearchInChannel))
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "slack_resolve_channels",
+		Description: "Resolve several channel names to IDs in one call. Returns a name to ID map and the names that were not found. Names not yet in the channel index are only looked up as far as the server's channel scan limit allows; call slack_list_channels first if names come back not found. Cheaper than resolving names one at a time.",
+	}, handle(workspaces, logger, "resolve_channels", (*slack.Service).ResolveChannels))
+}
+
+// registerResources exposes written response files as MCP resources, so clients
+// without filesystem access can read the files that tools refer to.
+func registerResources(server *mcp.Server, client *slack.Service, logger *zap.Logger) {
+	server.AddResourceTemplate(&mcp.ResourceTemplate{
+		Name:        "slack_response",
+		URITemplate: slack.ResponseURIPrefix + "{name}",
+		Description: "A response file written by a Slack tool. Use the uri field of a tool's file reference.",
+	}, func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+		uri := req.Params.URI
+		name := strings.TrimPrefix(uri, slack.ResponseURIPrefix)
+
+		data, err := client.ReadResponse(name)
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, mcp.ResourceNotFoundError(uri)
+		}
+		if err != nil {
+			logger.Warn("Failed to read response resource", zap.String("uri", uri), zap.Error(err))
+			return nil, err
+		}
+
+		contents := &mcp.ResourceContents{URI: uri, MIMEType: responseMIMEType(name)}
+		if strings.HasSuffix(name, ".gz") {
+			contents.Blob = data
+		} else {
+			contents.Text = string(data)
+		}
+		return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{contents}}, nil
+	})
+}
+
+// responseMIMEType returns the MIME type for a response file based on its extension
+func responseMIMEType(name string) string {
+	switch path.Ext(name) {
+	case ".json":
+		return "application/json"
+	case ".jsonl":
+		return "application/jsonl"
+	case ".gz":
+		return "application/gzip"
+	default:
+		return "text/plain"
+	}
+}
+
+// progressContext forwards tool progress to the client as MCP progress
+// notifications when the request carries a progress token.
+func progressContext(ctx context.Context, req *mcp.CallToolRequest) context.Context {
+	token := req.Params.GetProgressToken()
+	if token == nil || req.Session == nil {
+		return ctx
+	}
+	return slack.WithProgress(ctx, func(message string, progress float64) {
+		req.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+			ProgressToken: token,
+			Message:       message,
+			Progress:      progress,
+		})
 	})
 }
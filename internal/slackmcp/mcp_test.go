@@ -1,6 +1,9 @@
 package slackmcp
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"slices"
 	"testing"
 
@@ -14,14 +17,14 @@ import (
 func newTestClient(t *testing.T) *slack.Service {
 	ctrl := gomock.NewController(t)
 	api := slack.NewMockSlackAPI(ctrl)
-	return slack.NewService(api, zaptest.NewLogger(t), nil)
+	return slack.NewService(api, zaptest.NewLogger(t), nil, slack.Config{})
 }
 
 func TestCreateServer_ReturnsValidServer(t *testing.T) {
 	logger := zaptest.NewLogger(t)
 	client := newTestClient(t)
 
-	server := NewServer(logger, client)
+	server := NewServer(logger, SingleWorkspace(client))
 
 	if server == nil {
 		t.Fatal("CreateServer returned nil")
@@ -32,11 +35,12 @@ func TestServer_ListsAllRegisteredTools(t *testing.T) {
 	logger := zaptest.NewLogger(t)
 	client := newTestClient(t)
 
-	server := NewServer(logger, client)
+	server := NewServer(logger, SingleWorkspace(client))
 
 	clientTransport, serverTransport := mcp.NewInMemoryTransports()
 
-	ctx := t.Context()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
 	go func() {
 		server.Run(ctx, serverTransport)
@@ -67,6 +71,26 @@ func TestServer_ListsAllRegisteredTools(t *testing.T) {
 		"slack_read_thread",
 		"slack_export_channel",
 		"slack_read_canvas",
+		"slack_list_all_scheduled",
+		"slack_resolve_channel_refs",
+		"slack_canvas_stats",
+		"slack_thread_transcript",
+		"slack_large_files",
+		"slack_inactive_members",
+		"slack_channels_by_prefix",
+		"slack_top_posts",
+		"slack_list_pins",
+		"slack_user_threads",
+		"slack_check_links",
+		"slack_channel_manifest",
+		"slack_resolve_export",
+		"slack_get_message",
+		"slack_resolve_permalink",
+		"slack_whoami",
+		"slack_list_reminders",
+		"slack_get_channel_canvas_metadata",
+		"slack_search_in_channel",
+		"slack_resolve_channels",
 	}
 
 	if len(result.Tools) != len(wantTools) {
@@ -89,11 +113,12 @@ func TestServer_ToolsHaveDescriptions(t *testing.T) {
 	logger := zaptest.NewLogger(t)
 	client := newTestClient(t)
 
-	server := NewServer(logger, client)
+	server := NewServer(logger, SingleWorkspace(client))
 
 	clientTransport, serverTransport := mcp.NewInMemoryTransports()
 
-	ctx := t.Context()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
 	go func() {
 		server.Run(ctx, serverTransport)
@@ -126,7 +151,7 @@ func TestServer_CallToolInvokesHandler(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	api := slack.NewMockSlackAPI(ctrl)
 	logger := zaptest.NewLogger(t)
-	client := slack.NewService(api, logger, nil)
+	client := slack.NewService(api, logger, nil, slack.Config{})
 
 	api.EXPECT().
 		GetUserInfoContext(gomock.Any(), "U123456789").
@@ -136,11 +161,12 @@ func TestServer_CallToolInvokesHandler(t *testing.T) {
 			RealName: "Test User",
 		}, nil)
 
-	server := NewServer(logger, client)
+	server := NewServer(logger, SingleWorkspace(client))
 
 	clientTransport, serverTransport := mcp.NewInMemoryTransports()
 
-	ctx := t.Context()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
 	go func() {
 		server.Run(ctx, serverTransport)
@@ -171,3 +197,188 @@ func TestServer_CallToolInvokesHandler(t *testing.T) {
 		t.Errorf("tool call returned error: %v", result.Content)
 	}
 }
+
+func TestServer_RoutesCallToSelectedWorkspace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	logger := zaptest.NewLogger(t)
+	workAPI := slack.NewMockSlackAPI(ctrl)
+	homeAPI := slack.NewMockSlackAPI(ctrl)
+
+	homeAPI.EXPECT().
+		GetUserInfoContext(gomock.Any(), "U123456789").
+		Return(&goslack.User{ID: "U123456789", Name: "homeuser"}, nil)
+
+	workspaces, err := NewWorkspaces(map[string]*slack.Service{
+		"work": slack.NewService(workAPI, logger, nil, slack.Config{}),
+		"home": slack.NewService(homeAPI, logger, nil, slack.Config{}),
+	}, "work")
+	if err != nil {
+		t.Fatalf("NewWorkspaces: %v", err)
+	}
+	server := NewServer(logger, workspaces)
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		server.Run(ctx, serverTransport)
+	}()
+
+	mcpClient := mcp.NewClient(&mcp.Implementation{
+		Name:    "test-client",
+		Version: "1.0.0",
+	}, nil)
+
+	session, err := mcpClient.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client.Connect failed: %v", err)
+	}
+	defer session.Close()
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name: "slack_get_user",
+		Arguments: map[string]any{
+			"user":      "U123456789",
+			"workspace": "home",
+		},
+	})
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if result.IsError {
+		t.Errorf("tool call returned error: %v", result.Content)
+	}
+
+	result, err = session.CallTool(ctx, &mcp.CallToolParams{
+		Name: "slack_get_user",
+		Arguments: map[string]any{
+			"user":      "U123456789",
+			"workspace": "play",
+		},
+	})
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if !result.IsError {
+		t.Error("IsError for unknown workspace: got false, want true")
+	}
+}
+
+func TestServer_AuthErrorHasMeta(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	api := slack.NewMockSlackAPI(ctrl)
+	logger := zaptest.NewLogger(t)
+	client := slack.NewService(api, logger, nil, slack.Config{})
+
+	api.EXPECT().
+		GetUserInfoContext(gomock.Any(), "U123456789").
+		Return(nil, errors.New("invalid_auth"))
+
+	server := NewServer(logger, SingleWorkspace(client))
+
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		server.Run(ctx, serverTransport)
+	}()
+
+	mcpClient := mcp.NewClient(&mcp.Implementation{
+		Name:    "test-client",
+		Version: "1.0.0",
+	}, nil)
+
+	session, err := mcpClient.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client.Connect failed: %v", err)
+	}
+	defer session.Close()
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{
+		Name: "slack_get_user",
+		Arguments: map[string]any{
+			"user": "U123456789",
+		},
+	})
+	if err != nil {
+		t.Fatalf("CallTool failed: %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("IsError: got false, want true")
+	}
+
+	if result.StructuredContent != nil {
+		t.Errorf("StructuredContent: got %v, want none for an error result", result.StructuredContent)
+	}
+
+	raw, err := json.Marshal(result.Meta[authErrorMetaKey])
+	if err != nil {
+		t.Fatalf("marshal _meta: %v", err)
+	}
+	var got authErrorDetail
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatalf("unmarshal _meta %s: %v", raw, err)
+	}
+
+	want := authErrorDetail{
+		Code:        "invalid_auth",
+		Message:     "SLACK AUTHENTICATION ERROR: Authentication token is invalid. Please refresh your SLACK_TOKEN and SLACK_COOKIE. (code: invalid_auth)",
+		Remediation: "Authentication token is invalid. Please refresh your SLACK_TOKEN and SLACK_COOKIE.",
+	}
+	if got.Code != want.Code || got.Message != want.Message || got.Remediation != want.Remediation {
+		t.Errorf("auth error detail: got %+v, want %+v", got, want)
+	}
+}
+
+func TestServer_ReadsResponseResource(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	api := slack.NewMockSlackAPI(ctrl)
+	logger := zaptest.NewLogger(t)
+	responses := slack.NewMemoryResponseWriter()
+	client := slack.NewService(api, logger, responses, slack.Config{})
+
+	ref, err := responses.WriteText("canvas", "hello\n")
+	if err != nil {
+		t.Fatalf("WriteText failed: %v", err)
+	}
+
+	server := NewServer(logger, SingleWorkspace(client))
+	clientTransport, serverTransport := mcp.NewInMemoryTransports()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		server.Run(ctx, serverTransport)
+	}()
+
+	mcpClient := mcp.NewClient(&mcp.Implementation{
+		Name:    "test-client",
+		Version: "1.0.0",
+	}, nil)
+
+	session, err := mcpClient.Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("client.Connect failed: %v", err)
+	}
+	defer session.Close()
+
+	result, err := session.ReadResource(ctx, &mcp.ReadResourceParams{URI: ref.URI})
+	if err != nil {
+		t.Fatalf("ReadResource failed: %v", err)
+	}
+	if len(result.Contents) != 1 || result.Contents[0].Text != "hello\n" {
+		t.Errorf("Contents: got %+v, want hello", result.Contents)
+	}
+	if got := result.Contents[0].MIMEType; got != "text/plain" {
+		t.Errorf("MIMEType: got %q, want text/plain", got)
+	}
+
+	_, err = session.ReadResource(ctx, &mcp.ReadResourceParams{URI: slack.ResponseURIPrefix + "missing.json"})
+	if err == nil {
+		t.Error("ReadResource for missing file: got nil error, want not found")
+	}
+}
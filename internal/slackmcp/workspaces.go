@@ -0,0 +1,93 @@
+package slackmcp
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.mcconachie.co/slack-4-agents/internal/slack"
+	"go.uber.org/zap"
+)
+
+// Workspaces holds the Slack service for each configured workspace
+type Workspaces struct {
+	services    map[string]*slack.Service
+	defaultName string
+}
+
+// NewWorkspaces returns the workspaces in services. defaultName, which may be
+// empty, names the workspace used when a tool input does not select one.
+func NewWorkspaces(services map[string]*slack.Service, defaultName string) (*Workspaces, error) {
+	if len(services) == 0 {
+		return nil, fmt.Errorf("no workspaces configured")
+	}
+	if _, ok := services[defaultName]; defaultName != "" && !ok {
+		return nil, fmt.Errorf("default workspace %q is not configured", defaultName)
+	}
+	if defaultName == "" && len(services) == 1 {
+		for name := range services {
+			defaultName = name
+		}
+	}
+	return &Workspaces{services: services, defaultName: defaultName}, nil
+}
+
+// SingleWorkspace serves every call from client
+func SingleWorkspace(client *slack.Service) *Workspaces {
+	return &Workspaces{services: map[string]*slack.Service{"": client}}
+}
+
+// Get returns the service for the named workspace, or the default for ""
+func (w *Workspaces) Get(name string) (*slack.Service, error) {
+	if name == "" {
+		name = w.defaultName
+	}
+	if svc, ok := w.services[name]; ok {
+		return svc, nil
+	}
+	if name == "" {
+		return nil, fmt.Errorf("workspace is required; configured workspaces: %s", w.names())
+	}
+	return nil, fmt.Errorf("unknown workspace %q; configured workspaces: %s", name, w.names())
+}
+
+// responses returns a service for reading response files, which all
+// workspaces share
+func (w *Workspaces) responses() *slack.Service {
+	if svc, ok := w.services[w.defaultName]; ok {
+		return svc
+	}
+	return w.services[slices.Min(slices.Collect(maps.Keys(w.services)))]
+}
+
+func (w *Workspaces) names() string {
+	return strings.Join(slices.Sorted(maps.Keys(w.services)), ", ")
+}
+
+// workspaceInput is implemented by tool inputs through slack.WorkspaceSelector
+type workspaceInput interface {
+	WorkspaceName() string
+}
+
+// handle adapts a Service method into a tool handler that runs it against
+// the workspace selected by the input, with progress reporting and error
+// wrapping applied
+func handle[In workspaceInput, Out any](
+	workspaces *Workspaces,
+	logger *zap.Logger,
+	operation string,
+	method func(*slack.Service, context.Context, In) (Out, error),
+) mcp.ToolHandlerFor[In, Out] {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input In) (*mcp.CallToolResult, Out, error) {
+		client, err := workspaces.Get(input.WorkspaceName())
+		if err != nil {
+			var zero Out
+			return nil, zero, err
+		}
+		output, err := method(client, progressContext(ctx, req), input)
+		return nil, output, wrapError(ctx, logger, operation, err)
+	}
+}
@@ -0,0 +1,62 @@
+package slackmcp
+
+import (
+	"strings"
+	"testing"
+
+	"go.mcconachie.co/slack-4-agents/internal/slack"
+)
+
+func TestWorkspaces_Get(t *testing.T) {
+	work := &slack.Service{}
+	home := &slack.Service{}
+	services := map[string]*slack.Service{"work": work, "home": home}
+
+	withDefault, err := NewWorkspaces(services, "work")
+	if err != nil {
+		t.Fatalf("NewWorkspaces: %v", err)
+	}
+	noDefault, err := NewWorkspaces(services, "")
+	if err != nil {
+		t.Fatalf("NewWorkspaces: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		workspaces *Workspaces
+		workspace  string
+		want       *slack.Service
+		wantErr    string
+	}{
+		{name: "named", workspaces: withDefault, workspace: "home", want: home},
+		{name: "default", workspaces: withDefault, workspace: "", want: work},
+		{name: "unknown", workspaces: withDefault, workspace: "play", wantErr: `unknown workspace "play"; configured workspaces: home, work`},
+		{name: "no default", workspaces: noDefault, workspace: "", wantErr: "workspace is required; configured workspaces: home, work"},
+		{name: "single unnamed", workspaces: SingleWorkspace(work), workspace: "", want: work},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.workspaces.Get(tt.workspace)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Get(%q) error: got %v, want %q", tt.workspace, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Get(%q): %v", tt.workspace, err)
+			}
+			if got != tt.want {
+				t.Errorf("Get(%q): got wrong service", tt.workspace)
+			}
+		})
+	}
+}
+
+func TestNewWorkspaces_UnknownDefault(t *testing.T) {
+	_, err := NewWorkspaces(map[string]*slack.Service{"work": {}}, "home")
+	if err == nil {
+		t.Fatal("NewWorkspaces: got nil error, want error for unconfigured default")
+	}
+}